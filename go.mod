@@ -2,7 +2,13 @@ module terraform-provider-securden
 
 go 1.22.6
 
-require github.com/hashicorp/terraform-plugin-framework v1.14.1
+require (
+	github.com/hashicorp/terraform-plugin-framework v1.14.1
+	github.com/hashicorp/terraform-plugin-framework-validators v0.17.0
+	github.com/hashicorp/terraform-plugin-go v0.26.0
+	golang.org/x/crypto v0.32.0
+	golang.org/x/sync v0.10.0
+)
 
 require (
 	github.com/fatih/color v1.13.0 // indirect
@@ -10,7 +16,6 @@ require (
 	github.com/hashicorp/go-hclog v1.5.0 // indirect
 	github.com/hashicorp/go-plugin v1.6.2 // indirect
 	github.com/hashicorp/go-uuid v1.0.3 // indirect
-	github.com/hashicorp/terraform-plugin-go v0.26.0 // indirect
 	github.com/hashicorp/terraform-plugin-log v0.9.0 // indirect
 	github.com/hashicorp/terraform-registry-address v0.2.4 // indirect
 	github.com/hashicorp/terraform-svchost v0.1.1 // indirect
@@ -4,9 +4,13 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
@@ -21,13 +25,22 @@ type Account struct {
 }
 
 type AccountModel struct {
-	AccountID    types.Int64  `tfsdk:"account_id"`
-	AccountName  types.String `tfsdk:"account_name"`
-	AccountTitle types.String `tfsdk:"account_title"`
-	AccountType  types.String `tfsdk:"account_type"`
-	TicketID     types.String `tfsdk:"ticket_id"`
-	Reason 		 types.String `tfsdk:"reason"`
-	Account      types.Map    `tfsdk:"account"`
+	AccountID             types.Int64  `tfsdk:"account_id"`
+	AccountName           types.String `tfsdk:"account_name"`
+	AccountTitle          types.String `tfsdk:"account_title"`
+	AccountType           types.String `tfsdk:"account_type"`
+	TicketID              types.String `tfsdk:"ticket_id"`
+	Reason                types.String `tfsdk:"reason"`
+	ExpirationWarningDays types.Int64  `tfsdk:"expiration_warning_days"`
+	Address               types.String `tfsdk:"address"`
+	Password              types.String `tfsdk:"password"`
+	PrivateKey            types.String `tfsdk:"private_key"`
+	Port                  types.Int64  `tfsdk:"port"`
+	Tags                  types.List   `tfsdk:"tags"`
+	AdditionalFields      types.Map    `tfsdk:"additional_fields"`
+	Account               types.Map    `tfsdk:"account"`
+	ExpiresAt             types.String `tfsdk:"expires_at"`
+	DaysUntilExpiration   types.Int64  `tfsdk:"days_until_expiration"`
 }
 
 func (d *Account) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
@@ -67,11 +80,56 @@ func (d *Account) Schema(ctx context.Context, req datasource.SchemaRequest, resp
 				Optional:            true,
 				MarkdownDescription: "Reason for fetching account.",
 			},
+			"expiration_warning_days": schema.Int64Attribute{
+				Optional:            true,
+				MarkdownDescription: "Warn when the account has expired or expires within this many days. Overrides the provider `expiration_warning_days`, set to 0 to disable.",
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+			},
+			"address": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The address of the account.",
+			},
+			"password": schema.StringAttribute{
+				Computed:            true,
+				Sensitive:           true,
+				MarkdownDescription: "The password of the account.",
+			},
+			"private_key": schema.StringAttribute{
+				Computed:            true,
+				Sensitive:           true,
+				MarkdownDescription: "The private key of the account.",
+			},
+			"port": schema.Int64Attribute{
+				Computed:            true,
+				MarkdownDescription: "The port of the account, taken from the first available of `port`, `sql_server_port`, `mysql_port` and `oracle_port`.",
+			},
+			"tags": schema.ListAttribute{
+				ElementType:         types.StringType,
+				Computed:            true,
+				MarkdownDescription: "Tags associated with the account.",
+			},
+			"additional_fields": schema.MapAttribute{
+				ElementType:         types.StringType,
+				Computed:            true,
+				Sensitive:           true,
+				MarkdownDescription: "The additional (custom) fields of the account.",
+			},
 			"account": schema.MapAttribute{
 				ElementType:         types.StringType,
 				Computed:            true,
+				Sensitive:           true,
 				MarkdownDescription: "A map containing account attributes as keys and their corresponding values.",
 			},
+			"expires_at": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The expiration date of the account as an RFC 3339 timestamp, null when the account does not expire.",
+			},
+			"days_until_expiration": schema.Int64Attribute{
+				Computed:            true,
+				MarkdownDescription: "Number of days until the account expires, negative once it has expired.",
+			},
 		},
 	}
 }
@@ -111,14 +169,18 @@ func (d *Account) Create(ctx context.Context, req datasource.ReadRequest, resp *
 		)
 		return
 	}
-	var data AccountModel
-	var code int
-	var message string
-	data, code, message = get_account(ctx, account_id, account_name, account_title, account_type, ticket_id, reason)
-	if code != 200 {
-		resp.Diagnostics.AddWarning(fmt.Sprintf("%d - %s", code, message), "")
+	data, ok := read_account(ctx, &resp.Diagnostics, account_id, account_name, account_title, account_type, ticket_id, reason)
+	if !ok {
 		return
 	}
+	data.TicketID = account.TicketID
+	data.Reason = account.Reason
+	data.ExpirationWarningDays = account.ExpirationWarningDays
+	window := SecurdenExpirationWarningDays
+	if !account.ExpirationWarningDays.IsNull() {
+		window = account.ExpirationWarningDays.ValueInt64()
+	}
+	addExpirationWarning(&resp.Diagnostics, accountLabel(data.AccountTitle.ValueString(), data.AccountID.ValueInt64()), data.ExpiresAt, data.DaysUntilExpiration, window)
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
@@ -135,13 +197,49 @@ func (d *Account) Read(ctx context.Context, req datasource.ReadRequest, resp *da
 	account_type := account.AccountType.ValueString()
 	ticket_id := account.TicketID.ValueString()
 	reason := account.Reason.ValueString()
-	var data AccountModel
-	var code int
-	var message string
-	data, code, message = get_account(ctx, account_id, account_name, account_title, account_type, ticket_id, reason)
-	if code != 200 {
-		resp.Diagnostics.AddWarning(fmt.Sprintf("%d - %s", code, message), "")
+	data, ok := read_account(ctx, &resp.Diagnostics, account_id, account_name, account_title, account_type, ticket_id, reason)
+	if !ok {
 		return
 	}
+	data.TicketID = account.TicketID
+	data.Reason = account.Reason
+	data.ExpirationWarningDays = account.ExpirationWarningDays
+	window := SecurdenExpirationWarningDays
+	if !account.ExpirationWarningDays.IsNull() {
+		window = account.ExpirationWarningDays.ValueInt64()
+	}
+	addExpirationWarning(&resp.Diagnostics, accountLabel(data.AccountTitle.ValueString(), data.AccountID.ValueInt64()), data.ExpiresAt, data.DaysUntilExpiration, window)
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
+
+// read_account fetches an account, keeping its metadata in the offline cache
+// when one is configured, and serves the cached metadata instead while the
+// provider runs in offline mode.
+func read_account(ctx context.Context, diags *diag.Diagnostics, account_id int64, account_name, account_title, account_type, ticket_id, reason string) (AccountModel, bool) {
+	if isOfflineMode() {
+		entry, err := SecurdenOfflineCache.lookup(account_id, account_name, account_title)
+		if err != nil {
+			diags.AddWarning(
+				"Account Not Available Offline",
+				fmt.Sprintf("The Securden server is unreachable and %v.", err),
+			)
+			return AccountModel{}, false
+		}
+		diags.AddWarning(
+			"Securden Offline Mode",
+			fmt.Sprintf("The Securden server is unreachable, account %d was read from the offline cache (cached at %s). Secret attributes are null.", entry.AccountID, entry.CachedAt.Format(time.RFC3339)),
+		)
+		return entry.model(), true
+	}
+	data, code, message := get_account(ctx, account_id, account_name, account_title, account_type, ticket_id, reason)
+	if code != 200 {
+		diags.AddWarning(fmt.Sprintf("%d - %s", code, message), "")
+		return data, false
+	}
+	if SecurdenOfflineCache != nil {
+		if err := SecurdenOfflineCache.remember(data); err != nil {
+			diags.AddWarning("Unable to Update Offline Cache", err.Error())
+		}
+	}
+	return data, true
+}
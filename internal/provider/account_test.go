@@ -0,0 +1,89 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// testAccountModel is an account data source config with every collection
+// attribute typed, as the framework requires.
+func testAccountModel() AccountModel {
+	return AccountModel{
+		AccountID:        types.Int64Value(2000000001800),
+		Tags:             types.ListNull(types.StringType),
+		AdditionalFields: types.MapNull(types.StringType),
+		Account:          types.MapNull(types.StringType),
+	}
+}
+
+func TestAccountReadTypedAttributes(t *testing.T) {
+	getAccountServer(t, map[string]any{
+		"account_id":        2000000001800,
+		"account_title":     "Orders DB",
+		"account_name":      "orders",
+		"account_type":      "MySQL",
+		"address":           "db01.example.com",
+		"password":          "s3cret",
+		"mysql_port":        "3306",
+		"tags":              "prod, billing",
+		"replica_of":        "db00",
+		"additional_fields": map[string]any{"rotation": "quarterly"},
+	})
+	var got AccountModel
+	config := testAccountModel()
+	if diags := readDataSource(t, &Account{}, &config, &got); diags.HasError() {
+		t.Fatal(diags)
+	}
+	for name, pair := range map[string][2]string{
+		"account_id":    {got.AccountID.String(), "2000000001800"},
+		"account_title": {got.AccountTitle.ValueString(), "Orders DB"},
+		"account_name":  {got.AccountName.ValueString(), "orders"},
+		"account_type":  {got.AccountType.ValueString(), "MySQL"},
+		"address":       {got.Address.ValueString(), "db01.example.com"},
+		"password":      {got.Password.ValueString(), "s3cret"},
+		"port":          {got.Port.String(), "3306"},
+		"tags":          {got.Tags.String(), `["prod","billing"]`},
+	} {
+		if pair[0] != pair[1] {
+			t.Errorf("%s = %s, want %s", name, pair[0], pair[1])
+		}
+	}
+	if !got.PrivateKey.IsNull() {
+		t.Errorf("private_key = %s, want null", got.PrivateKey)
+	}
+	fields := got.AdditionalFields.Elements()
+	if len(fields) != 2 || fields["replica_of"].String() != `"db00"` || fields["rotation"].String() != `"quarterly"` {
+		t.Errorf("additional_fields = %s, want replica_of and rotation", got.AdditionalFields)
+	}
+	if got.Account.Elements()["mysql_port"].String() != `"3306"` {
+		t.Errorf("account = %s, want the raw fields kept", got.Account)
+	}
+}
+
+func TestCredentialAttributesSensitive(t *testing.T) {
+	tests := []struct {
+		dataSource datasource.DataSource
+		attributes []string
+	}{
+		{&Account{}, []string{"password", "private_key", "additional_fields", "account"}},
+		{&Accounts{}, []string{"accounts"}},
+		{&AddAccount{}, []string{"password"}},
+	}
+	for _, test := range tests {
+		var resp datasource.SchemaResponse
+		test.dataSource.Schema(context.Background(), datasource.SchemaRequest{}, &resp)
+		for _, name := range test.attributes {
+			if !resp.Schema.Attributes[name].IsSensitive() {
+				t.Errorf("%T attribute %s is not sensitive", test.dataSource, name)
+			}
+		}
+		for _, name := range []string{"address", "metadata"} {
+			if attribute, ok := resp.Schema.Attributes[name]; ok && attribute.IsSensitive() {
+				t.Errorf("%T attribute %s is sensitive", test.dataSource, name)
+			}
+		}
+	}
+}
@@ -0,0 +1,335 @@
+package provider
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"mime"
+	"net/http"
+	"os"
+	"path/filepath"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	dsschema "github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ datasource.DataSource = &AccountFile{}
+var _ ephemeral.EphemeralResource = &AccountFileEphemeral{}
+var _ ephemeral.EphemeralResourceWithConfigure = &AccountFileEphemeral{}
+
+func account_file() datasource.DataSource {
+	return &AccountFile{}
+}
+
+func account_file_ephemeral() ephemeral.EphemeralResource {
+	return &AccountFileEphemeral{}
+}
+
+type AccountFile struct {
+	client *http.Client
+}
+
+type AccountFileEphemeral struct {
+	client *http.Client
+}
+
+type AccountFileModel struct {
+	AccountID     types.Int64  `tfsdk:"account_id"`
+	AccountName   types.String `tfsdk:"account_name"`
+	AccountTitle  types.String `tfsdk:"account_title"`
+	TicketID      types.String `tfsdk:"ticket_id"`
+	Reason        types.String `tfsdk:"reason"`
+	FileName      types.String `tfsdk:"file_name"`
+	ContentType   types.String `tfsdk:"content_type"`
+	ContentBase64 types.String `tfsdk:"content_base64"`
+	Size          types.Int64  `tfsdk:"size"`
+}
+
+// AccountFileEphemeralModel adds output_path, which only the ephemeral
+// resource offers so the data source never writes files during a plan.
+type AccountFileEphemeralModel struct {
+	AccountFileModel
+	OutputPath types.String `tfsdk:"output_path"`
+}
+
+// accountFile is the file attached to an account_file account.
+type accountFile struct {
+	FileName    string
+	ContentType string
+	Content     []byte
+}
+
+func (d *AccountFile) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_account_file"
+}
+
+func (d *AccountFile) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = dsschema.Schema{
+		MarkdownDescription: "Retrieves the file attached to a Securden account. The content is stored in state, use the `securden_account_file` ephemeral resource to keep it out of state.",
+
+		Attributes: map[string]dsschema.Attribute{
+			"account_id": dsschema.Int64Attribute{
+				Optional:            true,
+				MarkdownDescription: "Unique identifier of the account.",
+			},
+			"account_name": dsschema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "The name associated with the account.",
+			},
+			"account_title": dsschema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Title or designation of the account.",
+			},
+			"ticket_id": dsschema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Ticket ID to be used for fetching the account.",
+			},
+			"reason": dsschema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Reason for fetching account.",
+			},
+			"file_name": dsschema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Name of the attached file.",
+			},
+			"content_type": dsschema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "MIME type of the attached file.",
+			},
+			"content_base64": dsschema.StringAttribute{
+				Computed:            true,
+				Sensitive:           true,
+				MarkdownDescription: "Base64 encoded content of the attached file.",
+			},
+			"size": dsschema.Int64Attribute{
+				Computed:            true,
+				MarkdownDescription: "Size of the attached file in bytes.",
+			},
+		},
+	}
+}
+
+func (d *AccountFile) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	client, ok := req.ProviderData.(*http.Client)
+	if !ok {
+		resp.Diagnostics.AddWarning(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *http.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+	d.client = client
+}
+
+func (d *AccountFile) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data AccountFileModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if _, ok := read_account_file(ctx, &data, &resp.Diagnostics); !ok {
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (d *AccountFileEphemeral) Metadata(ctx context.Context, req ephemeral.MetadataRequest, resp *ephemeral.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_account_file"
+}
+
+func (d *AccountFileEphemeral) Schema(ctx context.Context, req ephemeral.SchemaRequest, resp *ephemeral.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Retrieves the file attached to a Securden account without storing it in plan or state.",
+
+		Attributes: map[string]schema.Attribute{
+			"account_id": schema.Int64Attribute{
+				Optional:            true,
+				MarkdownDescription: "Unique identifier of the account.",
+			},
+			"account_name": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "The name associated with the account.",
+			},
+			"account_title": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Title or designation of the account.",
+			},
+			"ticket_id": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Ticket ID to be used for fetching the account.",
+			},
+			"reason": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Reason for fetching account.",
+			},
+			"output_path": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Local path the file is written to, readable by the owner only (mode `0600`). The file is written every time the ephemeral resource is opened.",
+			},
+			"file_name": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Name of the attached file.",
+			},
+			"content_type": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "MIME type of the attached file.",
+			},
+			"content_base64": schema.StringAttribute{
+				Computed:            true,
+				Sensitive:           true,
+				MarkdownDescription: "Base64 encoded content of the attached file.",
+			},
+			"size": schema.Int64Attribute{
+				Computed:            true,
+				MarkdownDescription: "Size of the attached file in bytes.",
+			},
+		},
+	}
+}
+
+func (d *AccountFileEphemeral) Configure(ctx context.Context, req ephemeral.ConfigureRequest, resp *ephemeral.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	client, ok := req.ProviderData.(*http.Client)
+	if !ok {
+		resp.Diagnostics.AddWarning(
+			"Unexpected Ephemeral Resource Configure Type",
+			fmt.Sprintf("Expected *http.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+	d.client = client
+}
+
+func (d *AccountFileEphemeral) Open(ctx context.Context, req ephemeral.OpenRequest, resp *ephemeral.OpenResponse) {
+	var data AccountFileEphemeralModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	content, ok := read_account_file(ctx, &data.AccountFileModel, &resp.Diagnostics)
+	if !ok {
+		return
+	}
+	if !data.OutputPath.IsNull() && data.OutputPath.ValueString() != "" {
+		if err := writeAccountFile(data.OutputPath.ValueString(), content); err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("output_path"), "Unable to Write File", err.Error())
+			return
+		}
+	}
+	resp.Diagnostics.Append(resp.Result.Set(ctx, &data)...)
+}
+
+// read_account_file fetches the attached file into data and returns its
+// content.
+func read_account_file(ctx context.Context, data *AccountFileModel, diags *diag.Diagnostics) ([]byte, bool) {
+	var account_id int64
+	if !data.AccountID.IsNull() {
+		account_id = data.AccountID.ValueInt64()
+	}
+	account_name := data.AccountName.ValueString()
+	account_title := data.AccountTitle.ValueString()
+	if account_id == 0 && account_name == "" && account_title == "" {
+		diags.AddError(
+			"Invalid Input",
+			"At least one of account_id, account_name, or account_title must be provided.",
+		)
+		return nil, false
+	}
+	file, code, message := get_account_file(ctx, account_id, account_name, account_title, data.TicketID.ValueString(), data.Reason.ValueString())
+	if code != 200 {
+		diags.AddError(fmt.Sprintf("%d - %s", code, message), "")
+		return nil, false
+	}
+	data.FileName = types.StringValue(file.FileName)
+	data.ContentType = types.StringValue(file.ContentType)
+	data.ContentBase64 = types.StringValue(base64.StdEncoding.EncodeToString(file.Content))
+	data.Size = types.Int64Value(int64(len(file.Content)))
+	return file.Content, true
+}
+
+// accountFileFromResponse decodes the account_file field of a get_account
+// response, either an object with the file name, content and content type or
+// the content alone.
+func accountFileFromResponse(response map[string]interface{}) (accountFile, error) {
+	var file accountFile
+	value, exists := response["account_file"]
+	if !exists || value == nil {
+		return file, fmt.Errorf("the account has no file attached")
+	}
+	var err error
+	if fields, ok := value.(map[string]interface{}); ok {
+		file.FileName = firstString(fields, "file_name", "filename", "name")
+		file.ContentType = firstString(fields, "content_type", "mime_type")
+		for _, key := range []string{"file_content", "content", "data"} {
+			if content, ok := fields[key]; ok {
+				value = content
+				break
+			}
+		}
+	}
+	if file.FileName == "" {
+		file.FileName = firstString(response, "file_name", "account_file_name")
+	}
+	file.Content, err = fileContent(value)
+	if err != nil {
+		return file, err
+	}
+	if file.ContentType == "" {
+		file.ContentType = mime.TypeByExtension(filepath.Ext(file.FileName))
+	}
+	if file.ContentType == "" {
+		file.ContentType = http.DetectContentType(file.Content)
+	}
+	return file, nil
+}
+
+// fileContent accepts file content as a base64 string or as a list of bytes.
+func fileContent(value interface{}) ([]byte, error) {
+	switch v := value.(type) {
+	case string:
+		content, err := base64.StdEncoding.DecodeString(v)
+		if err != nil {
+			return nil, fmt.Errorf("the account file content is not base64 encoded: %v", err)
+		}
+		return content, nil
+	case []interface{}:
+		content := make([]byte, 0, len(v))
+		for _, item := range v {
+			b, ok := int64Value(item)
+			if !ok || b < 0 || b > 255 {
+				return nil, fmt.Errorf("the account file content is not a list of bytes")
+			}
+			content = append(content, byte(b))
+		}
+		return content, nil
+	}
+	return nil, fmt.Errorf("unsupported account file content of type %T", value)
+}
+
+func firstString(fields map[string]interface{}, keys ...string) string {
+	for _, key := range keys {
+		if value := stringifyValue(fields[key]); value != "" {
+			return value
+		}
+	}
+	return ""
+}
+
+// writeAccountFile writes content to filePath, restricting an existing file
+// to the owner as well.
+func writeAccountFile(filePath string, content []byte) error {
+	if err := os.WriteFile(filePath, content, 0600); err != nil {
+		return err
+	}
+	return os.Chmod(filePath, 0600)
+}
@@ -0,0 +1,186 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestFileContent(t *testing.T) {
+	tests := []struct {
+		name  string
+		value interface{}
+		want  string
+	}{
+		{"base64 string", "aGVsbG8=", "hello"},
+		{"empty string", "", ""},
+		{"list of bytes", []interface{}{json.Number("104"), json.Number("105")}, "hi"},
+		{"list of numeric strings", []interface{}{"104", "105"}, "hi"},
+	}
+	for _, test := range tests {
+		got, err := fileContent(test.value)
+		if err != nil {
+			t.Errorf("%s: %v", test.name, err)
+			continue
+		}
+		if string(got) != test.want {
+			t.Errorf("%s: got %q, want %q", test.name, got, test.want)
+		}
+	}
+}
+
+func TestFileContentInvalid(t *testing.T) {
+	tests := map[string]interface{}{
+		"not base64":        "not base64!",
+		"byte out of range": []interface{}{json.Number("256")},
+		"negative byte":     []interface{}{json.Number("-1")},
+		"not a number":      []interface{}{true},
+		"unsupported type":  json.Number("42"),
+	}
+	for name, value := range tests {
+		if _, err := fileContent(value); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
+
+func TestAccountFileFromResponse(t *testing.T) {
+	tests := []struct {
+		name        string
+		response    string
+		fileName    string
+		contentType string
+		content     string
+	}{
+		{
+			name:        "object with metadata",
+			response:    `{"account_file": {"file_name": "id_rsa.pub", "content_type": "text/plain", "file_content": "aGVsbG8="}}`,
+			fileName:    "id_rsa.pub",
+			contentType: "text/plain",
+			content:     "hello",
+		},
+		{
+			name:        "content only with a top level file name",
+			response:    `{"account_file": "eyJhIjoxfQ==", "account_file_name": "config.json"}`,
+			fileName:    "config.json",
+			contentType: "application/json",
+			content:     `{"a":1}`,
+		},
+		{
+			name:        "content type detected from the content",
+			response:    `{"account_file": [104, 105]}`,
+			contentType: "text/plain; charset=utf-8",
+			content:     "hi",
+		},
+	}
+	for _, test := range tests {
+		var response map[string]interface{}
+		if err := decodeJSON([]byte(test.response), &response); err != nil {
+			t.Fatal(err)
+		}
+		file, err := accountFileFromResponse(response)
+		if err != nil {
+			t.Errorf("%s: %v", test.name, err)
+			continue
+		}
+		if file.FileName != test.fileName || file.ContentType != test.contentType || string(file.Content) != test.content {
+			t.Errorf("%s: got %q %q %q", test.name, file.FileName, file.ContentType, file.Content)
+		}
+	}
+
+	if _, err := accountFileFromResponse(map[string]interface{}{"account_title": "no file"}); err == nil {
+		t.Error("expected an error for an account without a file")
+	}
+}
+
+func TestWriteAccountFile(t *testing.T) {
+	filePath := filepath.Join(t.TempDir(), "secret.txt")
+	if err := os.WriteFile(filePath, []byte("old"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := writeAccountFile(filePath, []byte("new")); err != nil {
+		t.Fatal(err)
+	}
+	info, err := os.Stat(filePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if mode := info.Mode().Perm(); mode != 0600 {
+		t.Errorf("file mode = %o, want 600", mode)
+	}
+	if content, _ := os.ReadFile(filePath); string(content) != "new" {
+		t.Errorf("content = %q", content)
+	}
+}
+
+func TestAccountFileSchemasMatch(t *testing.T) {
+	ctx := context.Background()
+	var dataSource datasource.SchemaResponse
+	(&AccountFile{}).Schema(ctx, datasource.SchemaRequest{}, &dataSource)
+	var ephemeralResource ephemeral.SchemaResponse
+	(&AccountFileEphemeral{}).Schema(ctx, ephemeral.SchemaRequest{}, &ephemeralResource)
+
+	if _, ok := dataSource.Schema.Attributes["output_path"]; ok {
+		t.Error("the data source writes files through output_path")
+	}
+	for name, other := range ephemeralResource.Schema.Attributes {
+		attribute, ok := dataSource.Schema.Attributes[name]
+		if !ok {
+			if name != "output_path" {
+				t.Errorf("%s is missing from the data source", name)
+			}
+			continue
+		}
+		if attribute.IsOptional() != other.IsOptional() || attribute.IsComputed() != other.IsComputed() || attribute.IsSensitive() != other.IsSensitive() || attribute.GetMarkdownDescription() != other.GetMarkdownDescription() {
+			t.Errorf("%s differs between the data source and the ephemeral resource", name)
+		}
+	}
+	if len(ephemeralResource.Schema.Attributes) != len(dataSource.Schema.Attributes)+1 {
+		t.Errorf("data source has %d attributes, ephemeral resource %d", len(dataSource.Schema.Attributes), len(ephemeralResource.Schema.Attributes))
+	}
+	if !dataSource.Schema.Attributes["content_base64"].IsSensitive() {
+		t.Error("content_base64 is not sensitive")
+	}
+}
+
+func TestAccountFileEphemeralOpen(t *testing.T) {
+	ctx := context.Background()
+	newFakeServer(t, map[string]fakeHandler{
+		"/secretsmanagement/get_account": func(params map[string]any) any {
+			return map[string]any{"status_code": 200, "account_id": 2000000001800, "account_file": map[string]any{"file_name": "keystore.jks", "file_content": "aGVsbG8="}}
+		},
+	})
+	var schemaResp ephemeral.SchemaResponse
+	(&AccountFileEphemeral{}).Schema(ctx, ephemeral.SchemaRequest{}, &schemaResp)
+	outputPath := filepath.Join(t.TempDir(), "keystore.jks")
+	config := AccountFileEphemeralModel{
+		AccountFileModel: AccountFileModel{AccountID: types.Int64Value(2000000001800)},
+		OutputPath:       types.StringValue(outputPath),
+	}
+	configValue := tfsdk.EphemeralResultData{Schema: schemaResp.Schema}
+	if diags := configValue.Set(ctx, &config); diags.HasError() {
+		t.Fatal(diags)
+	}
+	resp := ephemeral.OpenResponse{Result: tfsdk.EphemeralResultData{Schema: schemaResp.Schema}}
+	(&AccountFileEphemeral{}).Open(ctx, ephemeral.OpenRequest{Config: tfsdk.Config{Schema: schemaResp.Schema, Raw: configValue.Raw}}, &resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatal(resp.Diagnostics)
+	}
+	var got AccountFileEphemeralModel
+	if diags := resp.Result.Get(ctx, &got); diags.HasError() {
+		t.Fatal(diags)
+	}
+	if got.FileName.ValueString() != "keystore.jks" || got.ContentBase64.ValueString() != "aGVsbG8=" || got.Size.ValueInt64() != 5 {
+		t.Errorf("got %s %s %s", got.FileName, got.ContentBase64, got.Size)
+	}
+	if content, err := os.ReadFile(outputPath); err != nil || string(content) != "hello" {
+		t.Errorf("output_path content = %q, %v", content, err)
+	}
+}
@@ -0,0 +1,518 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/resourcevalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ resource.Resource = &AccountResource{}
+var _ resource.ResourceWithConfigure = &AccountResource{}
+var _ resource.ResourceWithImportState = &AccountResource{}
+var _ resource.ResourceWithValidateConfig = &AccountResource{}
+var _ resource.ResourceWithConfigValidators = &AccountResource{}
+
+func account_resource() resource.Resource {
+	return &AccountResource{}
+}
+
+type AccountResource struct {
+	client *http.Client
+}
+
+type AccountResourceModel struct {
+	ID                        types.Int64  `tfsdk:"id"`
+	AccountName               types.String `tfsdk:"account_name"`
+	AccountTitle              types.String `tfsdk:"account_title"`
+	AccountType               types.String `tfsdk:"account_type"`
+	IPAddress                 types.String `tfsdk:"ipaddress"`
+	Notes                     types.String `tfsdk:"notes"`
+	Tags                      types.Set    `tfsdk:"tags"`
+	TagsMode                  types.String `tfsdk:"tags_mode"`
+	PersonalAccount           types.Bool   `tfsdk:"personal_account"`
+	AdditionalFields          types.Map    `tfsdk:"additional_fields"`
+	SensitiveAdditionalFields types.Map    `tfsdk:"sensitive_additional_fields"`
+	FolderID                  types.Int64  `tfsdk:"folder_id"`
+	FolderPath                types.String `tfsdk:"folder_path"`
+	Password                  types.String `tfsdk:"password"`
+	PasswordWO                types.String `tfsdk:"password_wo"`
+	PasswordWOVersion         types.Int64  `tfsdk:"password_wo_version"`
+	AccountExpirationDate     types.String `tfsdk:"account_expiration_date"`
+	AccountFile               types.String `tfsdk:"account_file"`
+	AccountFileName           types.String `tfsdk:"account_file_name"`
+	DistinguishedName         types.String `tfsdk:"distinguished_name"`
+	AccountAlias              types.String `tfsdk:"account_alias"`
+	DomainName                types.String `tfsdk:"domain_name"`
+	ExpiresAt                 types.String `tfsdk:"expires_at"`
+	DaysUntilExpiration       types.Int64  `tfsdk:"days_until_expiration"`
+}
+
+func (r *AccountResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_account"
+}
+
+func (r *AccountResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages an account in Securden.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.Int64Attribute{
+				Computed:            true,
+				MarkdownDescription: "Unique identifier of the account in Securden.",
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+			"account_title": schema.StringAttribute{
+				MarkdownDescription: "The title associated with the account.",
+				Required:            true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"account_name": schema.StringAttribute{
+				MarkdownDescription: "The name associated with the account.",
+				Optional:            true,
+			},
+			"account_type": schema.StringAttribute{
+				MarkdownDescription: "Specifies the type or category of the account. Changing this forces a new account to be created.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"password": schema.StringAttribute{
+				MarkdownDescription: "The password associated with the account. The value is stored in state, use `password_wo` to keep it out of state.",
+				Optional:            true,
+				Sensitive:           true,
+			},
+			"password_wo": schema.StringAttribute{
+				MarkdownDescription: "Write-only password associated with the account. The value is never stored in plan or state and is only sent to Securden on create or when `password_wo_version` changes.",
+				Optional:            true,
+				Sensitive:           true,
+				WriteOnly:           true,
+			},
+			"password_wo_version": schema.Int64Attribute{
+				MarkdownDescription: "Version of `password_wo`. Change this value to push a new write-only password to Securden.",
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.AlsoRequires(path.MatchRoot("password_wo")),
+				},
+			},
+			"personal_account": schema.BoolAttribute{
+				MarkdownDescription: "Indicates whether the account is personal (true/false). Changing this forces a new account to be created.",
+				Optional:            true,
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.RequiresReplace(),
+				},
+			},
+			"ipaddress": schema.StringAttribute{
+				MarkdownDescription: "The IP address of the account (if applicable).",
+				Optional:            true,
+				Validators: []validator.String{
+					hostAddress(),
+				},
+			},
+			"folder_id": schema.Int64Attribute{
+				MarkdownDescription: "The ID of the folder where the account is stored.",
+				Optional:            true,
+			},
+			"folder_path": schema.StringAttribute{
+				MarkdownDescription: "Path of the folder where the account is stored, for example `Prod/Databases/Oracle`. Resolved to a folder ID when the account is saved. Conflicts with `folder_id`.",
+				Optional:            true,
+			},
+			"notes": schema.StringAttribute{
+				MarkdownDescription: "Additional notes related to the account.",
+				Optional:            true,
+			},
+			"tags": schema.SetAttribute{
+				ElementType:         types.StringType,
+				MarkdownDescription: "Tags associated with the account. Tags changed outside of Terraform are reported as drift, in `additive` mode only for the configured tags. Removing `tags` from the configuration stops managing them and leaves the tags on the account in place, set `tags = []` to clear them.",
+				Optional:            true,
+				Validators:          tagsValidators(),
+			},
+			"tags_mode": schema.StringAttribute{
+				MarkdownDescription: "How `tags` is applied to the account. `authoritative` (default) replaces every tag on the account, `additive` only adds and removes the configured tags and keeps any other tag.",
+				Optional:            true,
+				Validators:          tagsModeValidators(),
+			},
+			"additional_fields": schema.MapAttribute{
+				ElementType:         types.StringType,
+				MarkdownDescription: "Additional (custom) fields of the account, such as an owner or a cost center, keyed by field name. Removing a field from the configuration clears it in Securden.",
+				Optional:            true,
+			},
+			"sensitive_additional_fields": schema.MapAttribute{
+				ElementType:         types.StringType,
+				MarkdownDescription: "Additional (custom) fields of the account holding secret values, keyed by field name. A field name cannot be set in both `additional_fields` and `sensitive_additional_fields`.",
+				Optional:            true,
+				Sensitive:           true,
+			},
+			"account_expiration_date": schema.StringAttribute{
+				MarkdownDescription: "The expiration date of the account, as a `DD/MM/YYYY` date, an RFC 3339 timestamp or a duration relative to apply time such as `+90d` or `+12w`. Sent to Securden as `DD/MM/YYYY`. Removing it clears the expiration date of the account.",
+				Optional:            true,
+				Validators: []validator.String{
+					expirationDate(),
+				},
+			},
+			"account_file": schema.StringAttribute{
+				MarkdownDescription: "Base64 encoded content of the file attached to the account, for example `filebase64(\"cert.pfx\")`. Uploaded on create and whenever the content or `account_file_name` changes.",
+				Optional:            true,
+				Sensitive:           true,
+				Validators: []validator.String{
+					base64Content(),
+					stringvalidator.AlsoRequires(path.MatchRoot("account_file_name")),
+				},
+			},
+			"account_file_name": schema.StringAttribute{
+				MarkdownDescription: "Name of the file attached to the account.",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+					stringvalidator.AlsoRequires(path.MatchRoot("account_file")),
+				},
+			},
+			"distinguished_name": schema.StringAttribute{
+				MarkdownDescription: "Required for LDAP domain accounts.",
+				Optional:            true,
+			},
+			"account_alias": schema.StringAttribute{
+				MarkdownDescription: "Required for AWS IAM accounts.",
+				Optional:            true,
+			},
+			"domain_name": schema.StringAttribute{
+				MarkdownDescription: "Required for Google Workspace accounts.",
+				Optional:            true,
+			},
+			"expires_at": schema.StringAttribute{
+				MarkdownDescription: "The expiration date of the account as an RFC 3339 timestamp, null when the account does not expire.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					expirationFromDate(),
+				},
+			},
+			"days_until_expiration": schema.Int64Attribute{
+				MarkdownDescription: "Number of days until the account expires, negative once it has expired.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.Int64{
+					expirationFromDate(),
+				},
+			},
+		},
+	}
+}
+
+func (r *AccountResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	client, ok := req.ProviderData.(*http.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *http.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+	r.client = client
+}
+
+func (r *AccountResource) ConfigValidators(ctx context.Context) []resource.ConfigValidator {
+	return []resource.ConfigValidator{
+		resourcevalidator.Conflicting(path.MatchRoot("password"), path.MatchRoot("password_wo")),
+		resourcevalidator.Conflicting(path.MatchRoot("folder_id"), path.MatchRoot("folder_path")),
+	}
+}
+
+func (r *AccountResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config AccountResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	validateAccountTypeFields(config.AccountType, map[string]types.String{
+		"distinguished_name": config.DistinguishedName,
+		"account_alias":      config.AccountAlias,
+		"domain_name":        config.DomainName,
+	}, &resp.Diagnostics)
+	validateAdditionalFields(ctx, config.AdditionalFields, config.SensitiveAdditionalFields, &resp.Diagnostics)
+}
+
+func (r *AccountResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan, config AccountResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	params := accountResourceParams(plan)
+	setFolderParam(ctx, params, plan.FolderPath, &resp.Diagnostics)
+	setExpirationParam(params, plan.AccountExpirationDate, &resp.Diagnostics)
+	if tags := tagsFromSet(ctx, plan.Tags, &resp.Diagnostics); tags != nil {
+		setTagsParam(params, tags)
+	}
+	setAdditionalFieldsParam(ctx, params, plan.AdditionalFields, plan.SensitiveAdditionalFields, nil, &resp.Diagnostics)
+	setAccountFileParam(params, plan)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	setParam(params, "account_type", plan.AccountType)
+	setParam(params, "personal_account", plan.PersonalAccount)
+	setParam(params, "password", plan.Password)
+	setParam(params, "password", config.PasswordWO)
+	added_account, code, message := add_account_function(ctx, params)
+	if code != 200 && code != 0 {
+		resp.Diagnostics.AddError(fmt.Sprintf("%d - %s", code, message), "")
+		return
+	}
+	plan.ID = added_account.ID
+	plan.PasswordWO = types.StringNull()
+	plan.ExpiresAt, plan.DaysUntilExpiration = expirationAttributes(stringifyValue(params["account_expiration_date"]), time.Now().UTC())
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *AccountResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state AccountResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	account, code, message := refresh_account(ctx, state.ID.ValueInt64())
+	if code == http.StatusNotFound {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if code != 200 {
+		resp.Diagnostics.AddError(fmt.Sprintf("%d - %s", code, message), "")
+		return
+	}
+	// account_title is required, so a null title means the state comes from
+	// an import and the attributes that are otherwise only tracked once
+	// configured are adopted from the server.
+	imported := state.AccountTitle.IsNull()
+	state.AccountTitle = refreshString(state.AccountTitle, account.AccountTitle)
+	state.AccountName = refreshString(state.AccountName, account.AccountName)
+	state.AccountType = refreshString(state.AccountType, account.AccountType)
+	state.IPAddress = refreshString(state.IPAddress, accountField(account, "ipaddress"))
+	state.Notes = refreshString(state.Notes, accountField(account, "notes"))
+	state.PersonalAccount = refreshBool(state.PersonalAccount, accountField(account, "personal_account"))
+	refreshFolder(ctx, &state, account, imported, &resp.Diagnostics)
+	state.Tags = refreshTags(ctx, state.Tags, state.TagsMode, account.Tags, &resp.Diagnostics)
+	state.AdditionalFields = refreshAdditionalFields(ctx, state.AdditionalFields, account.AdditionalFields, &resp.Diagnostics)
+	state.SensitiveAdditionalFields = refreshAdditionalFields(ctx, state.SensitiveAdditionalFields, account.AdditionalFields, &resp.Diagnostics)
+	state.ExpiresAt = account.ExpiresAt
+	state.DaysUntilExpiration = account.DaysUntilExpiration
+	addExpirationWarning(&resp.Diagnostics, accountLabel(state.AccountTitle.ValueString(), state.ID.ValueInt64()), state.ExpiresAt, state.DaysUntilExpiration, SecurdenExpirationWarningDays)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *AccountResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan, state, config AccountResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	params := accountResourceParams(plan)
+	setFolderParam(ctx, params, plan.FolderPath, &resp.Diagnostics)
+	// Relative expirations such as +90d are only re-evaluated when the
+	// configured value changes, not on every update.
+	if !plan.AccountExpirationDate.Equal(state.AccountExpirationDate) {
+		setExpirationParam(params, plan.AccountExpirationDate, &resp.Diagnostics)
+		if plan.AccountExpirationDate.IsNull() {
+			params["account_expiration_date"] = ""
+		}
+	}
+	// Send empty values to clear the fields removed from the configuration.
+	if plan.IPAddress.IsNull() && !state.IPAddress.IsNull() {
+		params["ipaddress"] = ""
+	}
+	if plan.Notes.IsNull() && !state.Notes.IsNull() {
+		params["notes"] = ""
+	}
+	if !plan.Tags.Equal(state.Tags) || !plan.TagsMode.Equal(state.TagsMode) {
+		setResourceTagsParam(ctx, params, state.ID.ValueInt64(), plan, state, &resp.Diagnostics)
+	}
+	if !plan.AdditionalFields.Equal(state.AdditionalFields) || !plan.SensitiveAdditionalFields.Equal(state.SensitiveAdditionalFields) {
+		setAdditionalFieldsParam(ctx, params, plan.AdditionalFields, plan.SensitiveAdditionalFields, removedAdditionalFields(ctx, plan, state, &resp.Diagnostics), &resp.Diagnostics)
+	}
+	if !plan.AccountFile.Equal(state.AccountFile) || !plan.AccountFileName.Equal(state.AccountFileName) {
+		setAccountFileParam(params, plan)
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	setParam(params, "account_id", state.ID)
+	setParam(params, "account_type", plan.AccountType)
+	if !plan.Password.Equal(state.Password) {
+		setParam(params, "password", plan.Password)
+	}
+	if !plan.PasswordWOVersion.Equal(state.PasswordWOVersion) {
+		setParam(params, "password", config.PasswordWO)
+	}
+	_, code, message := edit_account_function(ctx, params)
+	if code != 200 && code != 0 {
+		resp.Diagnostics.AddError(fmt.Sprintf("%d - %s", code, message), "")
+		return
+	}
+	plan.ID = state.ID
+	plan.PasswordWO = types.StringNull()
+	if expiration, ok := params["account_expiration_date"].(string); ok {
+		plan.ExpiresAt, plan.DaysUntilExpiration = expirationAttributes(expiration, time.Now().UTC())
+	} else {
+		plan.ExpiresAt, plan.DaysUntilExpiration = state.ExpiresAt, state.DaysUntilExpiration
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *AccountResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state AccountResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	params := make(map[string]any)
+	params["account_ids"] = []types.Int64{state.ID}
+	_, code, message := delete_accounts_function(ctx, params)
+	if code != 200 && code != 0 {
+		resp.Diagnostics.AddError(fmt.Sprintf("%d - %s", code, message), "")
+	}
+}
+
+func (r *AccountResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	id, err := strconv.ParseInt(req.ID, 10, 64)
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid Import ID", fmt.Sprintf("Expected a numeric account ID, got: %q", req.ID))
+		return
+	}
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), id)...)
+}
+
+func accountResourceParams(plan AccountResourceModel) map[string]any {
+	params := make(map[string]any)
+	setParam(params, "account_name", plan.AccountName)
+	setParam(params, "account_title", plan.AccountTitle)
+	setParam(params, "ipaddress", plan.IPAddress)
+	setParam(params, "notes", plan.Notes)
+	setParam(params, "folder_id", plan.FolderID)
+	setParam(params, "distinguished_name", plan.DistinguishedName)
+	setParam(params, "account_alias", plan.AccountAlias)
+	setParam(params, "domain_name", plan.DomainName)
+	return params
+}
+
+// setResourceTagsParam sends the planned tags. In additive mode the tags
+// already on the account are kept, except for the tags removed from the
+// configuration.
+func setResourceTagsParam(ctx context.Context, params map[string]any, accountID int64, plan, state AccountResourceModel, diags *diag.Diagnostics) {
+	tags := tagsFromSet(ctx, plan.Tags, diags)
+	if tags == nil {
+		return
+	}
+	if isAdditiveTags(plan.TagsMode) {
+		remote := accountTags(ctx, accountID, diags)
+		if diags.HasError() {
+			return
+		}
+		tags = mergeTags(remote, tagsFromSet(ctx, state.Tags, diags), tags)
+	}
+	setTagsParam(params, tags)
+}
+
+// refreshTags returns the tags read from Securden. Unmanaged tags stay null
+// and additive mode only tracks the configured tags.
+func refreshTags(ctx context.Context, current types.Set, mode types.String, remote types.List, diags *diag.Diagnostics) types.Set {
+	if current.IsNull() {
+		return current
+	}
+	tags := tagsFromList(ctx, remote, diags)
+	if isAdditiveTags(mode) {
+		tags = intersectTags(tagsFromSet(ctx, current, diags), tags)
+	}
+	return tagsToSet(tags)
+}
+
+// setAccountFileParam uploads the configured file with the account.
+func setAccountFileParam(params map[string]any, plan AccountResourceModel) {
+	if plan.AccountFile.IsNull() || plan.AccountFile.IsUnknown() {
+		return
+	}
+	params["account_file"] = map[string]string{
+		"file_name":    plan.AccountFileName.ValueString(),
+		"file_content": plan.AccountFile.ValueString(),
+	}
+}
+
+// accountField returns a top level field of the get_account response as a
+// string, null when the server omits it.
+func accountField(account AccountModel, key string) types.String {
+	if value, ok := account.Account.Elements()[key].(types.String); ok {
+		return value
+	}
+	return types.StringNull()
+}
+
+// refreshBool is the boolean counterpart of refreshString, false is treated
+// like an empty string.
+func refreshBool(current types.Bool, remote types.String) types.Bool {
+	value, err := strconv.ParseBool(remote.ValueString())
+	if remote.IsNull() || err != nil || (current.IsNull() && !value) {
+		return current
+	}
+	return types.BoolValue(value)
+}
+
+// refreshFolder reads back the folder of the account through the attribute
+// that is configured, folder_path is resolved from the folder ID reported by
+// the server. Accounts without a configured folder are left alone so the
+// folder Securden files them in does not show up as drift.
+func refreshFolder(ctx context.Context, state *AccountResourceModel, account AccountModel, imported bool, diags *diag.Diagnostics) {
+	folderID, ok := int64Value(accountField(account, "folder_id").ValueString())
+	if !ok {
+		return
+	}
+	switch {
+	case !state.FolderPath.IsNull():
+		entries, code, message := get_folders(ctx)
+		if code != 200 {
+			diags.AddWarning(fmt.Sprintf("%d - %s", code, message), "Unable to list folders to refresh folder_path.")
+			return
+		}
+		folderPath, ok := folderPaths(entries)[folderID]
+		if ok && !strings.EqualFold(folderPath, normalizeFolderPath(state.FolderPath.ValueString())) {
+			state.FolderPath = types.StringValue(folderPath)
+		}
+	case !state.FolderID.IsNull() || imported:
+		state.FolderID = types.Int64Value(folderID)
+	}
+}
+
+// refreshString returns the value read from Securden, keeping the current
+// value when the server omits the field or returns an empty string for an
+// attribute that is not configured.
+func refreshString(current, remote types.String) types.String {
+	if remote.IsNull() || (current.IsNull() && remote.ValueString() == "") {
+		return current
+	}
+	return remote
+}
@@ -0,0 +1,383 @@
+package provider
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// testAccountResourceModel is a minimal account with every collection
+// attribute typed, as the framework requires.
+func testAccountResourceModel() AccountResourceModel {
+	return AccountResourceModel{
+		ID:                        types.Int64Value(2000000001800),
+		AccountTitle:              types.StringValue("Orders DB"),
+		Tags:                      types.SetNull(types.StringType),
+		AdditionalFields:          types.MapNull(types.StringType),
+		SensitiveAdditionalFields: types.MapNull(types.StringType),
+	}
+}
+
+func editAccountServer(t *testing.T) *fakeServer {
+	return newFakeServer(t, map[string]fakeHandler{
+		"/api/edit_account": func(params map[string]any) any {
+			return map[string]any{"status_code": 200, "message": "Account updated successfully", "ID": 2000000001800}
+		},
+	})
+}
+
+func TestExpirationPlanModifier(t *testing.T) {
+	ctx := context.Background()
+	s := resourceSchema(t, &AccountResource{})
+	state := testAccountResourceModel()
+	state.AccountExpirationDate = types.StringValue("31/12/2030")
+	state.ExpiresAt = types.StringValue("2030-12-31T00:00:00Z")
+	state.DaysUntilExpiration = types.Int64Value(100)
+
+	tests := []struct {
+		name      string
+		date      types.String
+		noState   bool
+		wantAt    types.String
+		wantDays  types.Int64
+		wantKnown bool
+	}{
+		{name: "unchanged", date: types.StringValue("31/12/2030"), wantAt: state.ExpiresAt, wantDays: state.DaysUntilExpiration, wantKnown: true},
+		{name: "changed", date: types.StringValue("+30d")},
+		{name: "removed", date: types.StringNull(), wantAt: types.StringNull(), wantDays: types.Int64Null(), wantKnown: true},
+		{name: "create", date: types.StringValue("+30d"), noState: true},
+	}
+	for _, test := range tests {
+		plan := testAccountResourceModel()
+		plan.AccountExpirationDate = test.date
+		plan.ExpiresAt = types.StringUnknown()
+		plan.DaysUntilExpiration = types.Int64Unknown()
+		planned := newPlan(t, s, &plan)
+		current := newState(t, s, &state)
+		if test.noState {
+			current = tfsdk.State{Schema: s, Raw: current.Raw.Copy()}
+			current.RemoveResource(ctx)
+		}
+
+		stringResp := &planmodifier.StringResponse{PlanValue: plan.ExpiresAt}
+		expirationFromDate().PlanModifyString(ctx, planmodifier.StringRequest{
+			Plan: planned, State: current, PlanValue: plan.ExpiresAt, StateValue: state.ExpiresAt,
+		}, stringResp)
+		int64Resp := &planmodifier.Int64Response{PlanValue: plan.DaysUntilExpiration}
+		expirationFromDate().PlanModifyInt64(ctx, planmodifier.Int64Request{
+			Plan: planned, State: current, PlanValue: plan.DaysUntilExpiration, StateValue: state.DaysUntilExpiration,
+		}, int64Resp)
+		if stringResp.Diagnostics.HasError() || int64Resp.Diagnostics.HasError() {
+			t.Fatalf("%s: %v %v", test.name, stringResp.Diagnostics, int64Resp.Diagnostics)
+		}
+		if !test.wantKnown {
+			if !stringResp.PlanValue.IsUnknown() || !int64Resp.PlanValue.IsUnknown() {
+				t.Errorf("%s: got %s, %s, want unknown values", test.name, stringResp.PlanValue, int64Resp.PlanValue)
+			}
+			continue
+		}
+		if !stringResp.PlanValue.Equal(test.wantAt) || !int64Resp.PlanValue.Equal(test.wantDays) {
+			t.Errorf("%s: got %s, %s, want %s, %s", test.name, stringResp.PlanValue, int64Resp.PlanValue, test.wantAt, test.wantDays)
+		}
+	}
+}
+
+func TestAccountResourceExpirationModifiers(t *testing.T) {
+	s := resourceSchema(t, &AccountResource{})
+	if modifiers := s.Attributes["expires_at"].(schema.StringAttribute).PlanModifiers; len(modifiers) != 1 || modifiers[0] != expirationFromDate() {
+		t.Errorf("expires_at plan modifiers = %v", modifiers)
+	}
+	if modifiers := s.Attributes["days_until_expiration"].(schema.Int64Attribute).PlanModifiers; len(modifiers) != 1 || modifiers[0] != expirationFromDate() {
+		t.Errorf("days_until_expiration plan modifiers = %v", modifiers)
+	}
+	for _, modifier := range s.Attributes["id"].(schema.Int64Attribute).PlanModifiers {
+		if modifier == expirationFromDate() {
+			t.Error("id follows account_expiration_date")
+		}
+	}
+}
+
+func TestAccountResourceUpdateExpiration(t *testing.T) {
+	ctx := context.Background()
+	s := resourceSchema(t, &AccountResource{})
+	state := testAccountResourceModel()
+	state.AccountExpirationDate = types.StringValue("31/12/2030")
+	state.ExpiresAt = types.StringValue("2030-12-31T00:00:00Z")
+	state.DaysUntilExpiration = types.Int64Value(100)
+
+	t.Run("changed", func(t *testing.T) {
+		fake := editAccountServer(t)
+		plan := state
+		plan.AccountExpirationDate = types.StringValue("+30d")
+		plan.ExpiresAt = types.StringUnknown()
+		plan.DaysUntilExpiration = types.Int64Unknown()
+		resp := resource.UpdateResponse{State: tfsdk.State{Schema: s}}
+		(&AccountResource{}).Update(ctx, resource.UpdateRequest{
+			Plan:   newPlan(t, s, &plan),
+			State:  newState(t, s, &state),
+			Config: newConfig(t, s, &plan),
+		}, &resp)
+		if resp.Diagnostics.HasError() {
+			t.Fatal(resp.Diagnostics)
+		}
+		var got AccountResourceModel
+		getState(t, resp.State, &got)
+		want := time.Now().UTC().AddDate(0, 0, 30).Format(expirationDateLayout)
+		calls := fake.calls("/api/edit_account")
+		if len(calls) != 1 || calls[0]["account_expiration_date"] != want {
+			t.Fatalf("edit_account calls = %v, want account_expiration_date %s", calls, want)
+		}
+		if got.DaysUntilExpiration.ValueInt64() != 30 || got.ExpiresAt.IsNull() {
+			t.Errorf("got expires_at %s, days_until_expiration %s, want 30 days", got.ExpiresAt, got.DaysUntilExpiration)
+		}
+	})
+
+	t.Run("removed", func(t *testing.T) {
+		fake := editAccountServer(t)
+		plan := state
+		plan.AccountExpirationDate = types.StringNull()
+		plan.ExpiresAt = types.StringNull()
+		plan.DaysUntilExpiration = types.Int64Null()
+		resp := resource.UpdateResponse{State: tfsdk.State{Schema: s}}
+		(&AccountResource{}).Update(ctx, resource.UpdateRequest{
+			Plan:   newPlan(t, s, &plan),
+			State:  newState(t, s, &state),
+			Config: newConfig(t, s, &plan),
+		}, &resp)
+		if resp.Diagnostics.HasError() {
+			t.Fatal(resp.Diagnostics)
+		}
+		var got AccountResourceModel
+		getState(t, resp.State, &got)
+		calls := fake.calls("/api/edit_account")
+		if len(calls) != 1 || calls[0]["account_expiration_date"] != "" {
+			t.Fatalf("edit_account calls = %v, want the expiration date cleared", calls)
+		}
+		if !got.ExpiresAt.IsNull() || !got.DaysUntilExpiration.IsNull() {
+			t.Errorf("got expires_at %s, days_until_expiration %s, want null", got.ExpiresAt, got.DaysUntilExpiration)
+		}
+	})
+
+	t.Run("unchanged", func(t *testing.T) {
+		editAccountServer(t)
+		plan := state
+		plan.Notes = types.StringValue("rotated quarterly")
+		resp := resource.UpdateResponse{State: tfsdk.State{Schema: s}}
+		(&AccountResource{}).Update(ctx, resource.UpdateRequest{
+			Plan:   newPlan(t, s, &plan),
+			State:  newState(t, s, &state),
+			Config: newConfig(t, s, &plan),
+		}, &resp)
+		if resp.Diagnostics.HasError() {
+			t.Fatal(resp.Diagnostics)
+		}
+		var got AccountResourceModel
+		getState(t, resp.State, &got)
+		if !got.ExpiresAt.Equal(state.ExpiresAt) || !got.DaysUntilExpiration.Equal(state.DaysUntilExpiration) {
+			t.Errorf("got expires_at %s, days_until_expiration %s, want the state values", got.ExpiresAt, got.DaysUntilExpiration)
+		}
+	})
+}
+
+func getAccountServer(t *testing.T, account map[string]any) *fakeServer {
+	return newFakeServer(t, map[string]fakeHandler{
+		"/secretsmanagement/get_account": func(params map[string]any) any {
+			response := map[string]any{"status_code": 200}
+			for key, value := range account {
+				response[key] = value
+			}
+			return response
+		},
+		"/secretsmanagement/get_folders": func(params map[string]any) any {
+			return map[string]any{"status_code": 200, "folders": []map[string]any{
+				{"folder_id": 10, "folder_name": "Prod"},
+				{"folder_id": 12, "folder_name": "DB", "parent_folder_id": 10},
+				{"folder_id": 13, "folder_name": "Web", "parent_folder_id": 10},
+			}}
+		},
+	})
+}
+
+func readAccount(t *testing.T, state tfsdk.State) AccountResourceModel {
+	t.Helper()
+	resp := resource.ReadResponse{State: state}
+	(&AccountResource{}).Read(context.Background(), resource.ReadRequest{State: state}, &resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatal(resp.Diagnostics)
+	}
+	var got AccountResourceModel
+	getState(t, resp.State, &got)
+	return got
+}
+
+func TestAccountResourceRead(t *testing.T) {
+	s := resourceSchema(t, &AccountResource{})
+	getAccountServer(t, map[string]any{
+		"account_id":       2000000001800,
+		"account_title":    "Orders DB",
+		"account_name":     "orders",
+		"account_type":     "MySQL",
+		"ipaddress":        "10.0.0.12",
+		"notes":            "changed in the web console",
+		"folder_id":        13,
+		"personal_account": false,
+	})
+
+	t.Run("configured attributes", func(t *testing.T) {
+		state := testAccountResourceModel()
+		state.AccountType = types.StringValue("MySQL")
+		state.IPAddress = types.StringValue("10.0.0.11")
+		state.Notes = types.StringValue("primary")
+		state.FolderPath = types.StringValue("prod/db")
+		got := readAccount(t, newState(t, s, &state))
+		if got.IPAddress.ValueString() != "10.0.0.12" || got.Notes.ValueString() != "changed in the web console" {
+			t.Errorf("ipaddress = %s, notes = %s", got.IPAddress, got.Notes)
+		}
+		if got.FolderPath.ValueString() != "Prod/Web" || !got.FolderID.IsNull() {
+			t.Errorf("folder_path = %s, folder_id = %s, want the moved folder path only", got.FolderPath, got.FolderID)
+		}
+		if !got.PersonalAccount.IsNull() {
+			t.Errorf("personal_account = %s, want null", got.PersonalAccount)
+		}
+	})
+
+	t.Run("unconfigured attributes", func(t *testing.T) {
+		state := testAccountResourceModel()
+		state.AccountType = types.StringValue("MySQL")
+		got := readAccount(t, newState(t, s, &state))
+		if !got.FolderID.IsNull() || !got.FolderPath.IsNull() {
+			t.Errorf("folder_id = %s, folder_path = %s, want null", got.FolderID, got.FolderPath)
+		}
+	})
+
+	t.Run("import", func(t *testing.T) {
+		ctx := context.Background()
+		resp := resource.ImportStateResponse{State: tfsdk.State{Schema: s, Raw: tftypes.NewValue(s.Type().TerraformType(ctx), nil)}}
+		(&AccountResource{}).ImportState(ctx, resource.ImportStateRequest{ID: "2000000001800"}, &resp)
+		if resp.Diagnostics.HasError() {
+			t.Fatal(resp.Diagnostics)
+		}
+		got := readAccount(t, resp.State)
+		want := AccountResourceModel{
+			ID:           types.Int64Value(2000000001800),
+			AccountTitle: types.StringValue("Orders DB"),
+			AccountName:  types.StringValue("orders"),
+			AccountType:  types.StringValue("MySQL"),
+			IPAddress:    types.StringValue("10.0.0.12"),
+			Notes:        types.StringValue("changed in the web console"),
+			FolderID:     types.Int64Value(13),
+		}
+		for name, pair := range map[string][2]attr.Value{
+			"id":               {got.ID, want.ID},
+			"account_title":    {got.AccountTitle, want.AccountTitle},
+			"account_name":     {got.AccountName, want.AccountName},
+			"account_type":     {got.AccountType, want.AccountType},
+			"ipaddress":        {got.IPAddress, want.IPAddress},
+			"notes":            {got.Notes, want.Notes},
+			"folder_id":        {got.FolderID, want.FolderID},
+			"folder_path":      {got.FolderPath, types.StringNull()},
+			"personal_account": {got.PersonalAccount, types.BoolNull()},
+		} {
+			if !pair[0].Equal(pair[1]) {
+				t.Errorf("%s = %s, want %s", name, pair[0], pair[1])
+			}
+		}
+	})
+}
+
+func TestRefreshBool(t *testing.T) {
+	tests := []struct {
+		current types.Bool
+		remote  types.String
+		want    types.Bool
+	}{
+		{types.BoolNull(), types.StringValue("false"), types.BoolNull()},
+		{types.BoolNull(), types.StringValue("true"), types.BoolValue(true)},
+		{types.BoolValue(true), types.StringValue("false"), types.BoolValue(false)},
+		{types.BoolValue(true), types.StringNull(), types.BoolValue(true)},
+		{types.BoolValue(true), types.StringValue("yes please"), types.BoolValue(true)},
+	}
+	for _, test := range tests {
+		if got := refreshBool(test.current, test.remote); !got.Equal(test.want) {
+			t.Errorf("refreshBool(%s, %s) = %s, want %s", test.current, test.remote, got, test.want)
+		}
+	}
+}
+
+func TestAccountResourceUpdateClearsFields(t *testing.T) {
+	s := resourceSchema(t, &AccountResource{})
+	fake := editAccountServer(t)
+	state := testAccountResourceModel()
+	state.IPAddress = types.StringValue("10.0.0.12")
+	state.Notes = types.StringValue("primary")
+	plan := testAccountResourceModel()
+	plan.IPAddress = state.IPAddress
+	resp := resource.UpdateResponse{State: tfsdk.State{Schema: s}}
+	(&AccountResource{}).Update(context.Background(), resource.UpdateRequest{
+		Plan:   newPlan(t, s, &plan),
+		State:  newState(t, s, &state),
+		Config: newConfig(t, s, &plan),
+	}, &resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatal(resp.Diagnostics)
+	}
+	calls := fake.calls("/api/edit_account")
+	if len(calls) != 1 || calls[0]["notes"] != "" || calls[0]["ipaddress"] != "10.0.0.12" {
+		t.Errorf("edit_account calls = %v, want notes cleared and ipaddress kept", calls)
+	}
+}
+
+func TestAccountResourceValidateConfig(t *testing.T) {
+	ctx := context.Background()
+	s := resourceSchema(t, &AccountResource{})
+	tests := []struct {
+		name       string
+		configure  func(*AccountResourceModel)
+		wantErrors int
+	}{
+		{"valid", func(m *AccountResourceModel) { m.AccountType = types.StringValue("MySQL") }, 0},
+		{"ldap without distinguished_name", func(m *AccountResourceModel) { m.AccountType = types.StringValue("LDAP Domain Account") }, 1},
+		{"ldap with distinguished_name", func(m *AccountResourceModel) {
+			m.AccountType = types.StringValue("LDAP Domain Account")
+			m.DistinguishedName = types.StringValue("CN=svc,DC=example,DC=com")
+		}, 0},
+		{"aws without account_alias", func(m *AccountResourceModel) { m.AccountType = types.StringValue("AWS IAM") }, 1},
+		{"google workspace with empty domain_name", func(m *AccountResourceModel) {
+			m.AccountType = types.StringValue("Google Workspace")
+			m.DomainName = types.StringValue("")
+		}, 1},
+		{"password and password_wo", func(m *AccountResourceModel) {
+			m.Password = types.StringValue("s3cret")
+			m.PasswordWO = types.StringValue("s3cret")
+		}, 1},
+		{"folder_id and folder_path", func(m *AccountResourceModel) {
+			m.FolderID = types.Int64Value(12)
+			m.FolderPath = types.StringValue("Prod/DB")
+		}, 1},
+	}
+	for _, test := range tests {
+		model := testAccountResourceModel()
+		model.ID = types.Int64Null()
+		test.configure(&model)
+		req := resource.ValidateConfigRequest{Config: newConfig(t, s, &model)}
+		var resp resource.ValidateConfigResponse
+		r := &AccountResource{}
+		r.ValidateConfig(ctx, req, &resp)
+		for _, validator := range r.ConfigValidators(ctx) {
+			var validatorResp resource.ValidateConfigResponse
+			validator.ValidateResource(ctx, req, &validatorResp)
+			resp.Diagnostics.Append(validatorResp.Diagnostics...)
+		}
+		if got := resp.Diagnostics.ErrorsCount(); got != test.wantErrors {
+			t.Errorf("%s: got %d errors, want %d: %v", test.name, got, test.wantErrors, resp.Diagnostics)
+		}
+	}
+}
@@ -4,9 +4,15 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"sort"
+	"strconv"
+	"strings"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
@@ -21,8 +27,20 @@ type Accounts struct {
 }
 
 type AccountsModel struct {
-	AccountIDs []types.Int64                `tfsdk:"account_ids"`
-	Accounts   map[string]map[string]string `tfsdk:"accounts"`
+	AccountIDs    []types.Int64                    `tfsdk:"account_ids"`
+	ChunkSize     types.Int64                      `tfsdk:"chunk_size"`
+	FailOnMissing types.Bool                       `tfsdk:"fail_on_missing"`
+	Accounts      map[string]map[string]string     `tfsdk:"accounts"`
+	Metadata      map[string]AccountsMetadataModel `tfsdk:"metadata"`
+	MissingIDs    []types.Int64                    `tfsdk:"missing_ids"`
+	Errors        map[string]string                `tfsdk:"errors"`
+}
+
+type AccountsMetadataModel struct {
+	AccountName  types.String `tfsdk:"account_name"`
+	AccountTitle types.String `tfsdk:"account_title"`
+	AccountType  types.String `tfsdk:"account_type"`
+	Address      types.String `tfsdk:"address"`
 }
 
 func (d *Accounts) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
@@ -38,12 +56,58 @@ func (d *Accounts) Schema(ctx context.Context, req datasource.SchemaRequest, res
 				ElementType:         types.Int64Type,
 				MarkdownDescription: "A list of account IDs to fetch details for.",
 				Required:            true,
+				Validators: []validator.List{
+					listvalidator.SizeAtLeast(1),
+				},
+			},
+			"chunk_size": schema.Int64Attribute{
+				Optional:            true,
+				MarkdownDescription: "Number of account IDs fetched per request. Larger lists are split into chunks that are fetched concurrently. Defaults to 100.",
+			},
+			"fail_on_missing": schema.BoolAttribute{
+				Optional:            true,
+				MarkdownDescription: "Fail instead of warning when any of the requested accounts cannot be retrieved. Defaults to false.",
+			},
+			"missing_ids": schema.ListAttribute{
+				ElementType:         types.Int64Type,
+				Computed:            true,
+				MarkdownDescription: "Requested account IDs that could not be retrieved.",
+			},
+			"errors": schema.MapAttribute{
+				ElementType:         types.StringType,
+				Computed:            true,
+				MarkdownDescription: "A map of every requested account ID that could not be retrieved to the reason of the failure.",
 			},
 			"accounts": schema.MapAttribute{
 				ElementType:         types.MapType{ElemType: types.StringType},
 				Computed:            true,
+				Sensitive:           true,
 				MarkdownDescription: "A map containing multiple account details, where each key represents an account ID and the value is a map of account attributes.",
 			},
+			"metadata": schema.MapNestedAttribute{
+				Computed:            true,
+				MarkdownDescription: "Non-secret account metadata keyed by account ID, usable in `for_each` and outputs.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"account_name": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "The name associated with the account.",
+						},
+						"account_title": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Title or designation of the account.",
+						},
+						"account_type": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Specifies the type or category of the account.",
+						},
+						"address": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "The address of the account.",
+						},
+					},
+				},
+			},
 		},
 	}
 }
@@ -67,15 +131,37 @@ func (d *Accounts) Create(ctx context.Context, req datasource.ReadRequest, resp
 	var accounts AccountsModel
 	resp.Diagnostics.Append(req.Config.Get(ctx, &accounts)...)
 
-	params := make(map[string]any)
-	params["account_ids"] = accounts.AccountIDs
+	var accountIDs []int64
+	for _, id := range accounts.AccountIDs {
+		if !id.IsNull() && !id.IsUnknown() {
+			accountIDs = append(accountIDs, id.ValueInt64())
+		}
+	}
 
-	accountsData, code, message := get_accounts(ctx, params)
-	if code != 200 {
-		resp.Diagnostics.AddWarning(fmt.Sprintf("%d - %s", code, message), "")
+	var accountsData map[string]map[string]string
+	var failures map[string]string
+	if isOfflineMode() {
+		accountsData, failures = SecurdenOfflineCache.accountsData(accountIDs)
+		resp.Diagnostics.AddWarning(
+			"Securden Offline Mode",
+			"The Securden server is unreachable, account metadata was read from the offline cache. Secret attributes are not available.",
+		)
+	} else {
+		accountsData, failures = get_accounts_in_chunks(ctx, accountIDs, int(accounts.ChunkSize.ValueInt64()))
+	}
+	addAccountFailureDiagnostics(&resp.Diagnostics, failures, accounts.FailOnMissing.ValueBool())
+	if resp.Diagnostics.HasError() {
 		return
 	}
 	accounts.Accounts = accountsData
+	accounts.Metadata = accountsMetadata(accountsData)
+	accounts.Errors = failures
+	accounts.MissingIDs = []types.Int64{}
+	for _, id := range accountIDs {
+		if _, missing := failures[strconv.FormatInt(id, 10)]; missing {
+			accounts.MissingIDs = append(accounts.MissingIDs, types.Int64Value(id))
+		}
+	}
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &accounts)...)
 }
@@ -84,15 +170,82 @@ func (d *Accounts) Read(ctx context.Context, req datasource.ReadRequest, resp *d
 	var accounts AccountsModel
 	resp.Diagnostics.Append(req.Config.Get(ctx, &accounts)...)
 
-	params := make(map[string]any)
-	params["account_ids"] = accounts.AccountIDs
+	var accountIDs []int64
+	for _, id := range accounts.AccountIDs {
+		if !id.IsNull() && !id.IsUnknown() {
+			accountIDs = append(accountIDs, id.ValueInt64())
+		}
+	}
 
-	accountsData, code, message := get_accounts(ctx, params)
-	if code != 200 {
-		resp.Diagnostics.AddWarning(fmt.Sprintf("%d - %s", code, message), "")
+	var accountsData map[string]map[string]string
+	var failures map[string]string
+	if isOfflineMode() {
+		accountsData, failures = SecurdenOfflineCache.accountsData(accountIDs)
+		resp.Diagnostics.AddWarning(
+			"Securden Offline Mode",
+			"The Securden server is unreachable, account metadata was read from the offline cache. Secret attributes are not available.",
+		)
+	} else {
+		accountsData, failures = get_accounts_in_chunks(ctx, accountIDs, int(accounts.ChunkSize.ValueInt64()))
+	}
+	addAccountFailureDiagnostics(&resp.Diagnostics, failures, accounts.FailOnMissing.ValueBool())
+	if resp.Diagnostics.HasError() {
 		return
 	}
 	accounts.Accounts = accountsData
+	accounts.Metadata = accountsMetadata(accountsData)
+	accounts.Errors = failures
+	accounts.MissingIDs = []types.Int64{}
+	for _, id := range accountIDs {
+		if _, missing := failures[strconv.FormatInt(id, 10)]; missing {
+			accounts.MissingIDs = append(accounts.MissingIDs, types.Int64Value(id))
+		}
+	}
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &accounts)...)
 }
+
+func accountsMetadata(accountsData map[string]map[string]string) map[string]AccountsMetadataModel {
+	metadata := make(map[string]AccountsMetadataModel, len(accountsData))
+	for id, fields := range accountsData {
+		metadata[id] = AccountsMetadataModel{
+			AccountName:  metadataString(fields, "account_name"),
+			AccountTitle: metadataString(fields, "account_title"),
+			AccountType:  metadataString(fields, "account_type"),
+			Address:      metadataString(fields, "address"),
+		}
+	}
+	return metadata
+}
+
+func metadataString(fields map[string]string, key string) types.String {
+	if value, ok := fields[key]; ok {
+		return types.StringValue(value)
+	}
+	return types.StringNull()
+}
+
+// addAccountFailureDiagnostics reports the accounts that could not be
+// retrieved, grouping the account IDs by failure reason.
+func addAccountFailureDiagnostics(diags *diag.Diagnostics, failures map[string]string, asErrors bool) {
+	byReason := make(map[string][]string)
+	for id, reason := range failures {
+		byReason[reason] = append(byReason[reason], id)
+	}
+	reasons := make([]string, 0, len(byReason))
+	for reason := range byReason {
+		reasons = append(reasons, reason)
+	}
+	sort.Strings(reasons)
+	for _, reason := range reasons {
+		ids := byReason[reason]
+		sort.Strings(ids)
+		summary := fmt.Sprintf("Unable to retrieve %d account(s)", len(ids))
+		detail := fmt.Sprintf("%s: %s", reason, strings.Join(ids, ", "))
+		if asErrors {
+			diags.AddError(summary, detail)
+		} else {
+			diags.AddWarning(summary, detail)
+		}
+	}
+}
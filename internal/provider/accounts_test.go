@@ -0,0 +1,176 @@
+package provider
+
+import (
+	"context"
+	"strconv"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// accountsServer answers get_accounts with the requested accounts that are
+// present in known, in the shape of the Securden bulk response.
+func accountsServer(t *testing.T, known map[string]map[string]any) *fakeServer {
+	return newFakeServer(t, map[string]fakeHandler{
+		"/secretsmanagement/get_accounts": func(params map[string]any) any {
+			response := map[string]any{"status_code": 200}
+			ids, _ := params["account_ids"].([]any)
+			for _, id := range ids {
+				if account, ok := known[stringifyValue(id)]; ok {
+					response[stringifyValue(id)] = account
+				}
+			}
+			return response
+		},
+	})
+}
+
+func testAccountsModel(ids ...int64) AccountsModel {
+	model := AccountsModel{}
+	for _, id := range ids {
+		model.AccountIDs = append(model.AccountIDs, types.Int64Value(id))
+	}
+	return model
+}
+
+func TestAccountsReadMetadata(t *testing.T) {
+	accountsServer(t, map[string]map[string]any{
+		"2000000001800": {"account_name": "orders", "account_title": "Orders DB", "account_type": "MySQL", "address": "db01.example.com", "password": "s3cret"},
+	})
+	var got AccountsModel
+	if diags := readDataSource(t, &Accounts{}, testAccountsModel(2000000001800), &got); diags.HasError() {
+		t.Fatal(diags)
+	}
+	metadata, ok := got.Metadata["2000000001800"]
+	if !ok {
+		t.Fatalf("metadata = %v, want account 2000000001800", got.Metadata)
+	}
+	want := AccountsMetadataModel{
+		AccountName:  types.StringValue("orders"),
+		AccountTitle: types.StringValue("Orders DB"),
+		AccountType:  types.StringValue("MySQL"),
+		Address:      types.StringValue("db01.example.com"),
+	}
+	if metadata != want {
+		t.Errorf("metadata = %+v, want %+v", metadata, want)
+	}
+	if got.Accounts["2000000001800"]["password"] != "s3cret" {
+		t.Errorf("accounts = %v, want the password kept in the sensitive map", got.Accounts)
+	}
+}
+
+func TestGetAccountsInChunks(t *testing.T) {
+	known := make(map[string]map[string]any)
+	var ids []int64
+	for id := int64(2000000001801); id <= 2000000001805; id++ {
+		ids = append(ids, id)
+		known[strconv.FormatInt(id, 10)] = map[string]any{"account_id": id, "account_title": "Orders DB"}
+	}
+	fake := accountsServer(t, known)
+	SecurdenMaxParallelRequests = 2
+
+	accounts, failures := get_accounts_in_chunks(context.Background(), ids, 2)
+	if len(accounts) != 5 || len(failures) != 0 {
+		t.Fatalf("got %d accounts and failures %v, want 5 accounts", len(accounts), failures)
+	}
+	calls := fake.calls("/secretsmanagement/get_accounts")
+	if len(calls) != 3 {
+		t.Fatalf("get_accounts calls = %d, want 3", len(calls))
+	}
+	requested := make(map[string]bool)
+	for _, call := range calls {
+		chunk := call["account_ids"].([]any)
+		if len(chunk) > 2 {
+			t.Errorf("chunk %v exceeds chunk_size", chunk)
+		}
+		for _, id := range chunk {
+			requested[stringifyValue(id)] = true
+		}
+	}
+	if len(requested) != 5 {
+		t.Errorf("requested ids = %v, want every id once", requested)
+	}
+}
+
+func TestGetAccountsInChunksFailedChunk(t *testing.T) {
+	newFakeServer(t, map[string]fakeHandler{
+		"/secretsmanagement/get_accounts": func(params map[string]any) any {
+			ids := params["account_ids"].([]any)
+			if stringifyValue(ids[0]) == "2000000001803" {
+				return map[string]any{"status_code": 500, "message": "Internal error"}
+			}
+			response := map[string]any{"status_code": 200}
+			for _, id := range ids {
+				response[stringifyValue(id)] = map[string]any{"account_id": id}
+			}
+			return response
+		},
+	})
+	accounts, failures := get_accounts_in_chunks(context.Background(), []int64{2000000001801, 2000000001802, 2000000001803, 2000000001804}, 2)
+	if len(accounts) != 2 {
+		t.Errorf("accounts = %v, want the first chunk", accounts)
+	}
+	for _, id := range []string{"2000000001803", "2000000001804"} {
+		if failures[id] != "500 - Internal error" {
+			t.Errorf("failure of %s = %q, want the chunk error", id, failures[id])
+		}
+	}
+}
+
+func TestAccountsReadFailures(t *testing.T) {
+	handlers := map[string]fakeHandler{
+		"/secretsmanagement/get_accounts": func(params map[string]any) any {
+			return map[string]any{
+				"status_code":   200,
+				"2000000001801": map[string]any{"account_id": 2000000001801, "account_title": "Orders DB"},
+				"2000000001802": nil,
+				"2000000001803": map[string]any{"status_code": 403, "message": "Access denied"},
+				"errors":        map[string]any{"2000000001804": "Account is locked"},
+			}
+		},
+	}
+	ids := []int64{2000000001801, 2000000001802, 2000000001803, 2000000001804, 2000000001805}
+	wantErrors := map[string]string{
+		"2000000001802": "Account not found",
+		"2000000001803": "403 - Access denied",
+		"2000000001804": "Account is locked",
+		"2000000001805": "Account not returned by Securden",
+	}
+
+	t.Run("warn", func(t *testing.T) {
+		newFakeServer(t, handlers)
+		var got AccountsModel
+		diags := readDataSource(t, &Accounts{}, testAccountsModel(ids...), &got)
+		if diags.HasError() {
+			t.Fatal(diags)
+		}
+		if diags.WarningsCount() != len(wantErrors) {
+			t.Errorf("got %d warnings, want one per reason: %v", diags.WarningsCount(), diags)
+		}
+		if len(got.Accounts) != 1 {
+			t.Errorf("accounts = %v, want 2000000001801 only", got.Accounts)
+		}
+		if len(got.Errors) != len(wantErrors) {
+			t.Errorf("errors = %v, want %v", got.Errors, wantErrors)
+		}
+		for id, reason := range wantErrors {
+			if got.Errors[id] != reason {
+				t.Errorf("error of %s = %q, want %q", id, got.Errors[id], reason)
+			}
+		}
+		if len(got.MissingIDs) != 4 || got.MissingIDs[0].ValueInt64() != 2000000001802 || got.MissingIDs[3].ValueInt64() != 2000000001805 {
+			t.Errorf("missing_ids = %v, want the failed ids in request order", got.MissingIDs)
+		}
+	})
+
+	t.Run("fail_on_missing", func(t *testing.T) {
+		newFakeServer(t, handlers)
+		config := testAccountsModel(ids...)
+		config.FailOnMissing = types.BoolValue(true)
+		var got AccountsModel
+		diags := readDataSource(t, &Accounts{}, config, &got)
+		if diags.ErrorsCount() != len(wantErrors) {
+			t.Errorf("got %d errors, want one per reason: %v", diags.ErrorsCount(), diags)
+		}
+	})
+}
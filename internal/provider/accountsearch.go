@@ -0,0 +1,217 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ datasource.DataSource = &AccountSearch{}
+
+func account_search() datasource.DataSource {
+	return &AccountSearch{}
+}
+
+type AccountSearch struct {
+	client *http.Client
+}
+
+type AccountSearchModel struct {
+	AccountType     types.String          `tfsdk:"account_type"`
+	FolderID        types.Int64           `tfsdk:"folder_id"`
+	Tags            []types.String        `tfsdk:"tags"`
+	NameContains    types.String          `tfsdk:"name_contains"`
+	TitleContains   types.String          `tfsdk:"title_contains"`
+	PersonalAccount types.Bool            `tfsdk:"personal_account"`
+	MaxResults      types.Int64           `tfsdk:"max_results"`
+	IDs             []types.Int64         `tfsdk:"ids"`
+	Accounts        []AccountSummaryModel `tfsdk:"accounts"`
+}
+
+type AccountSummaryModel struct {
+	AccountID       types.Int64    `tfsdk:"account_id"`
+	AccountName     types.String   `tfsdk:"account_name"`
+	AccountTitle    types.String   `tfsdk:"account_title"`
+	AccountType     types.String   `tfsdk:"account_type"`
+	FolderID        types.Int64    `tfsdk:"folder_id"`
+	PersonalAccount types.Bool     `tfsdk:"personal_account"`
+	Tags            []types.String `tfsdk:"tags"`
+}
+
+func (d *AccountSearch) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_account_search"
+}
+
+func (d *AccountSearch) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Searches accounts in Securden and returns a summary of every matching account.",
+
+		Attributes: map[string]schema.Attribute{
+			"account_type": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Only return accounts of this type.",
+			},
+			"folder_id": schema.Int64Attribute{
+				Optional:            true,
+				MarkdownDescription: "Only return accounts stored in this folder.",
+			},
+			"tags": schema.ListAttribute{
+				ElementType:         types.StringType,
+				Optional:            true,
+				MarkdownDescription: "Only return accounts carrying all of these tags.",
+			},
+			"name_contains": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Only return accounts whose name contains this text (case-insensitive).",
+			},
+			"title_contains": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Only return accounts whose title contains this text (case-insensitive).",
+			},
+			"personal_account": schema.BoolAttribute{
+				Optional:            true,
+				MarkdownDescription: "Only return personal (true) or shared (false) accounts.",
+			},
+			"max_results": maxResultsAttribute("Maximum number of matching accounts to return, the first ones in the order the server lists them. Pages are fetched until this many accounts match every filter. Defaults to no limit."),
+			"ids": schema.ListAttribute{
+				ElementType:         types.Int64Type,
+				Computed:            true,
+				MarkdownDescription: "IDs of the matching accounts, in the order the server lists them.",
+			},
+			"accounts": schema.ListNestedAttribute{
+				Computed:            true,
+				MarkdownDescription: "Summaries of the matching accounts.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: accountSummaryAttributes(),
+				},
+			},
+		},
+	}
+}
+
+func accountSummaryAttributes() map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"account_id": schema.Int64Attribute{
+			Computed:            true,
+			MarkdownDescription: "Unique identifier of the account.",
+		},
+		"account_name": schema.StringAttribute{
+			Computed:            true,
+			MarkdownDescription: "The name associated with the account.",
+		},
+		"account_title": schema.StringAttribute{
+			Computed:            true,
+			MarkdownDescription: "Title or designation of the account.",
+		},
+		"account_type": schema.StringAttribute{
+			Computed:            true,
+			MarkdownDescription: "Specifies the type or category of the account.",
+		},
+		"folder_id": schema.Int64Attribute{
+			Computed:            true,
+			MarkdownDescription: "The ID of the folder where the account is stored.",
+		},
+		"personal_account": schema.BoolAttribute{
+			Computed:            true,
+			MarkdownDescription: "Indicates whether the account is personal.",
+		},
+		"tags": schema.ListAttribute{
+			ElementType:         types.StringType,
+			Computed:            true,
+			MarkdownDescription: "Tags associated with the account.",
+		},
+	}
+}
+
+func (d *AccountSearch) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	client, ok := req.ProviderData.(*http.Client)
+	if !ok {
+		resp.Diagnostics.AddWarning(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *http.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+	d.client = client
+}
+
+func (d *AccountSearch) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var search AccountSearchModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &search)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	params := make(map[string]any)
+	setParam(params, "account_type", search.AccountType)
+	setParam(params, "folder_id", search.FolderID)
+	setParam(params, "personal_account", search.PersonalAccount)
+	setParam(params, "search_text", search.NameContains)
+	var tags []string
+	for _, tag := range search.Tags {
+		if !tag.IsNull() && !tag.IsUnknown() {
+			tags = append(tags, tag.ValueString())
+		}
+	}
+	if len(tags) > 0 {
+		params["tags"] = strings.Join(tags, ",")
+	}
+	accounts, code, message := search_accounts(ctx, params, int(search.MaxResults.ValueInt64()), func(account AccountSummaryModel) bool {
+		return search.matches(account, tags)
+	})
+	if code != 200 {
+		resp.Diagnostics.AddWarning(fmt.Sprintf("%d - %s", code, message), "")
+		return
+	}
+	search.Accounts = []AccountSummaryModel{}
+	search.IDs = []types.Int64{}
+	for _, account := range accounts {
+		search.Accounts = append(search.Accounts, account)
+		search.IDs = append(search.IDs, account.AccountID)
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &search)...)
+}
+
+// matches re-applies the filters locally so that the result is exact even if
+// the server treats a filter as a fuzzy match or ignores it.
+func (search AccountSearchModel) matches(account AccountSummaryModel, tags []string) bool {
+	if !search.AccountType.IsNull() && !strings.EqualFold(search.AccountType.ValueString(), account.AccountType.ValueString()) {
+		return false
+	}
+	if !search.FolderID.IsNull() && search.FolderID.ValueInt64() != account.FolderID.ValueInt64() {
+		return false
+	}
+	if !search.PersonalAccount.IsNull() && search.PersonalAccount.ValueBool() != account.PersonalAccount.ValueBool() {
+		return false
+	}
+	if !containsFold(account.AccountName.ValueString(), search.NameContains.ValueString()) {
+		return false
+	}
+	if !containsFold(account.AccountTitle.ValueString(), search.TitleContains.ValueString()) {
+		return false
+	}
+	for _, tag := range tags {
+		found := false
+		for _, accountTag := range account.Tags {
+			if strings.EqualFold(accountTag.ValueString(), tag) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+func containsFold(value, substr string) bool {
+	return strings.Contains(strings.ToLower(value), strings.ToLower(substr))
+}
@@ -0,0 +1,53 @@
+package provider
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestAccountSearchRead(t *testing.T) {
+	// The server ignores the filters, so matching is left to the provider.
+	accounts := []map[string]any{
+		{"account_id": 2000000001801, "account_name": "orders", "account_title": "Orders DB", "account_type": "MySQL", "tags": "prod,billing"},
+		{"account_id": 2000000001802, "account_name": "web", "account_title": "Web DB", "account_type": "mysql", "tags": []string{"prod"}},
+		{"account_id": 2000000001803, "account_name": "stats", "account_title": "Stats DB", "account_type": "PostgreSQL", "tags": "prod,billing"},
+		{"account_id": 2000000001804, "account_name": "ledger", "account_title": "Ledger DB", "account_type": "MySQL", "tags": "Billing,prod", "folder_id": 12, "personal_account": "false"},
+		{"account_id": 2000000001805, "account_name": "archive", "account_title": "Archive", "account_type": "MySQL", "tags": "prod,billing"},
+		{"account_id": 2000000001806, "account_name": "payroll", "account_title": "Payroll DB", "account_type": "MySQL", "tags": "billing,prod"},
+	}
+	fake := newFakeServer(t, map[string]fakeHandler{
+		"/secretsmanagement/search_accounts": func(params map[string]any) any {
+			offset, _ := strconv.Atoi(stringifyValue(params["offset"]))
+			limit, _ := strconv.Atoi(stringifyValue(params["limit"]))
+			end := min(offset+limit, len(accounts))
+			return map[string]any{"status_code": 200, "accounts": accounts[min(offset, end):end], "total_count": len(accounts)}
+		},
+	})
+	setPaging(t, 2, 1)
+
+	var got AccountSearchModel
+	if diags := readDataSource(t, &AccountSearch{}, &AccountSearchModel{
+		AccountType:   types.StringValue("MySQL"),
+		Tags:          []types.String{types.StringValue("billing"), types.StringValue("prod")},
+		TitleContains: types.StringValue("db"),
+		MaxResults:    types.Int64Value(2),
+	}, &got); diags.HasError() {
+		t.Fatal(diags)
+	}
+	if len(got.IDs) != 2 || got.IDs[0].ValueInt64() != 2000000001801 || got.IDs[1].ValueInt64() != 2000000001804 {
+		t.Errorf("ids = %v, want the first two matches in server order", got.IDs)
+	}
+	if len(got.Accounts) != 2 || got.Accounts[1].FolderID.ValueInt64() != 12 || !got.Accounts[1].PersonalAccount.Equal(types.BoolValue(false)) {
+		t.Errorf("accounts = %+v", got.Accounts)
+	}
+
+	calls := fake.calls("/secretsmanagement/search_accounts")
+	if len(calls) < 2 {
+		t.Fatalf("search_accounts calls = %v, want the result collected across pages", calls)
+	}
+	if calls[0]["account_type"] != "MySQL" || calls[0]["tags"] != "billing,prod" {
+		t.Errorf("search_accounts params = %v, want the filters passed on", calls[0])
+	}
+}
@@ -0,0 +1,413 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/resourcevalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ resource.Resource = &AccountShareResource{}
+var _ resource.ResourceWithConfigure = &AccountShareResource{}
+var _ resource.ResourceWithImportState = &AccountShareResource{}
+var _ resource.ResourceWithValidateConfig = &AccountShareResource{}
+var _ resource.ResourceWithConfigValidators = &AccountShareResource{}
+
+// sharePermissions are the access levels that can be granted, from the
+// least to the most privileged.
+var sharePermissions = []string{"view", "modify", "manage"}
+
+// sharePrincipalTypes are the kinds of principals an account or folder can
+// be shared with.
+var sharePrincipalTypes = []string{"user", "user_group"}
+
+var shareGrantAttrTypes = map[string]attr.Type{
+	"principal_type": types.StringType,
+	"principal_id":   types.Int64Type,
+	"permission":     types.StringType,
+}
+
+func account_share() resource.Resource {
+	return &AccountShareResource{}
+}
+
+type AccountShareResource struct {
+	client *http.Client
+}
+
+type AccountShareResourceModel struct {
+	ID        types.String `tfsdk:"id"`
+	AccountID types.Int64  `tfsdk:"account_id"`
+	FolderID  types.Int64  `tfsdk:"folder_id"`
+	Grants    types.Set    `tfsdk:"grants"`
+}
+
+type ShareGrantModel struct {
+	PrincipalType types.String `tfsdk:"principal_type"`
+	PrincipalID   types.Int64  `tfsdk:"principal_id"`
+	Permission    types.String `tfsdk:"permission"`
+}
+
+// shareGrant is a single permission as exchanged with the share endpoints.
+type shareGrant struct {
+	PrincipalType string
+	PrincipalID   int64
+	Permission    string
+}
+
+// managed reports whether the grant is one the resource controls, ownership
+// and other server-side access levels are left alone.
+func (grant shareGrant) managed() bool {
+	return slices.Contains(sharePermissions, grant.Permission) && slices.Contains(sharePrincipalTypes, grant.PrincipalType)
+}
+
+func (grant shareGrant) key() string {
+	return grant.PrincipalType + ":" + strconv.FormatInt(grant.PrincipalID, 10)
+}
+
+func (r *AccountShareResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_account_share"
+}
+
+func (r *AccountShareResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages the users and user groups an account or folder is shared with. The resource is authoritative, shares that are not listed in `grants` are revoked. Creating the resource fails when the account or folder already has shares that are not listed in `grants`, import them instead.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Identifier of the shared item, in the form `account:<id>` or `folder:<id>`.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"account_id": schema.Int64Attribute{
+				Optional:            true,
+				MarkdownDescription: "ID of the account to share. Exactly one of `account_id` and `folder_id` must be set. Changing this forces a new resource to be created.",
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplace(),
+				},
+			},
+			"folder_id": schema.Int64Attribute{
+				Optional:            true,
+				MarkdownDescription: "ID of the folder to share. Exactly one of `account_id` and `folder_id` must be set. Changing this forces a new resource to be created.",
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplace(),
+				},
+			},
+			"grants": schema.SetNestedAttribute{
+				Required:            true,
+				MarkdownDescription: "The permissions granted on the account or folder.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"principal_type": schema.StringAttribute{
+							Required:            true,
+							MarkdownDescription: "Kind of principal, one of `user` or `user_group`.",
+							Validators: []validator.String{
+								stringvalidator.OneOf(sharePrincipalTypes...),
+							},
+						},
+						"principal_id": schema.Int64Attribute{
+							Required:            true,
+							MarkdownDescription: "ID of the user or user group.",
+						},
+						"permission": schema.StringAttribute{
+							Required:            true,
+							MarkdownDescription: "Access granted to the principal, one of `view`, `modify` or `manage`.",
+							Validators: []validator.String{
+								stringvalidator.OneOf(sharePermissions...),
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (r *AccountShareResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	client, ok := req.ProviderData.(*http.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *http.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+	r.client = client
+}
+
+func (r *AccountShareResource) ConfigValidators(ctx context.Context) []resource.ConfigValidator {
+	return []resource.ConfigValidator{
+		resourcevalidator.ExactlyOneOf(path.MatchRoot("account_id"), path.MatchRoot("folder_id")),
+	}
+}
+
+// ValidateConfig rejects a principal listed more than once, which a set
+// allows when the permissions differ.
+func (r *AccountShareResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config AccountShareResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if config.Grants.IsUnknown() {
+		return
+	}
+	var grants []ShareGrantModel
+	resp.Diagnostics.Append(config.Grants.ElementsAs(ctx, &grants, true)...)
+	seen := make(map[string]bool)
+	for _, grant := range grants {
+		if grant.PrincipalType.IsUnknown() || grant.PrincipalID.IsUnknown() {
+			continue
+		}
+		key := grant.PrincipalType.ValueString() + ":" + strconv.FormatInt(grant.PrincipalID.ValueInt64(), 10)
+		if seen[key] {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("grants"),
+				"Duplicate Grant",
+				fmt.Sprintf("The %s %d is granted more than one permission, list it once.", grant.PrincipalType.ValueString(), grant.PrincipalID.ValueInt64()),
+			)
+		}
+		seen[key] = true
+	}
+}
+
+func (r *AccountShareResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan AccountShareResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	planned := shareGrantsFromSet(ctx, plan.Grants, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	current, code, message := get_shares(ctx, plan.targetParams())
+	if code != 200 {
+		resp.Diagnostics.AddError(fmt.Sprintf("%d - %s", code, message), "")
+		return
+	}
+	if unlisted := unlistedGrants(current, planned); len(unlisted) > 0 {
+		resp.Diagnostics.AddError(
+			"Existing Shares",
+			fmt.Sprintf("The %s is already shared with %s, which grants does not list. Add them to grants, or import the existing shares with `terraform import` using the ID %q and adjust grants afterwards.", plan.targetDescription(), strings.Join(unlisted, ", "), plan.targetID()),
+		)
+		return
+	}
+	if !r.apply(ctx, plan, current, planned, &resp.Diagnostics) {
+		return
+	}
+	plan.ID = types.StringValue(plan.targetID())
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *AccountShareResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state AccountShareResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	grants, code, message := get_shares(ctx, state.targetParams())
+	if code == http.StatusNotFound {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if code != 200 {
+		resp.Diagnostics.AddError(fmt.Sprintf("%d - %s", code, message), "")
+		return
+	}
+	state.Grants = shareGrantsToSet(grants, &resp.Diagnostics)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *AccountShareResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan, state AccountShareResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	planned := shareGrantsFromSet(ctx, plan.Grants, &resp.Diagnostics)
+	current := shareGrantsFromSet(ctx, state.Grants, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if !r.apply(ctx, plan, current, planned, &resp.Diagnostics) {
+		return
+	}
+	plan.ID = state.ID
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *AccountShareResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state AccountShareResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	current := shareGrantsFromSet(ctx, state.Grants, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	r.apply(ctx, state, current, nil, &resp.Diagnostics)
+}
+
+func (r *AccountShareResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	kind, rawID, _ := strings.Cut(req.ID, ":")
+	id, err := strconv.ParseInt(rawID, 10, 64)
+	if err != nil || (kind != "account" && kind != "folder") {
+		resp.Diagnostics.AddError("Invalid Import ID", fmt.Sprintf("Expected account:<id> or folder:<id>, got: %q", req.ID))
+		return
+	}
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), req.ID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root(kind+"_id"), id)...)
+}
+
+// apply moves the shares of the target from current to planned. Principals
+// that are no longer listed are revoked first, then new and changed grants
+// are sent in a single request.
+func (r *AccountShareResource) apply(ctx context.Context, model AccountShareResourceModel, current, planned []shareGrant, diags *diag.Diagnostics) bool {
+	wanted := make(map[string]shareGrant, len(planned))
+	for _, grant := range planned {
+		wanted[grant.key()] = grant
+	}
+	existing := make(map[string]shareGrant, len(current))
+	for _, grant := range current {
+		if grant.managed() {
+			existing[grant.key()] = grant
+		}
+	}
+	revoked := []map[string]any{}
+	for key, grant := range existing {
+		if _, ok := wanted[key]; !ok {
+			revoked = append(revoked, map[string]any{
+				"principal_type": grant.PrincipalType,
+				"principal_id":   grant.PrincipalID,
+			})
+		}
+	}
+	granted := []map[string]any{}
+	for key, grant := range wanted {
+		if previous, ok := existing[key]; ok && previous.Permission == grant.Permission {
+			continue
+		}
+		granted = append(granted, map[string]any{
+			"principal_type": grant.PrincipalType,
+			"principal_id":   grant.PrincipalID,
+			"permission":     grant.Permission,
+		})
+	}
+	if len(revoked) > 0 {
+		params := model.targetParams()
+		params["shares"] = revoked
+		_, code, message := write_request(ctx, params, "/api/unshare", DELETE)
+		if code != 200 && code != http.StatusNotFound {
+			diags.AddError(fmt.Sprintf("%d - %s", code, message), "")
+			return false
+		}
+	}
+	if len(granted) > 0 {
+		params := model.targetParams()
+		params["shares"] = granted
+		_, code, message := write_request(ctx, params, "/api/share", POST)
+		if code != 200 {
+			diags.AddError(fmt.Sprintf("%d - %s", code, message), "")
+			return false
+		}
+	}
+	return true
+}
+
+// unlistedGrants describes the managed grants in current that planned does
+// not list, for example "user 12 (view)", in a stable order.
+func unlistedGrants(current, planned []shareGrant) []string {
+	wanted := make(map[string]bool, len(planned))
+	for _, grant := range planned {
+		wanted[grant.key()] = true
+	}
+	unlisted := []string{}
+	for _, grant := range current {
+		if grant.managed() && !wanted[grant.key()] {
+			unlisted = append(unlisted, fmt.Sprintf("%s %d (%s)", grant.PrincipalType, grant.PrincipalID, grant.Permission))
+		}
+	}
+	sort.Strings(unlisted)
+	return unlisted
+}
+
+func (model AccountShareResourceModel) targetDescription() string {
+	if !model.FolderID.IsNull() {
+		return fmt.Sprintf("folder %d", model.FolderID.ValueInt64())
+	}
+	return fmt.Sprintf("account %d", model.AccountID.ValueInt64())
+}
+
+func (model AccountShareResourceModel) targetParams() map[string]any {
+	params := make(map[string]any)
+	setParam(params, "account_id", model.AccountID)
+	setParam(params, "folder_id", model.FolderID)
+	return params
+}
+
+func (model AccountShareResourceModel) targetID() string {
+	if !model.FolderID.IsNull() {
+		return "folder:" + strconv.FormatInt(model.FolderID.ValueInt64(), 10)
+	}
+	return "account:" + strconv.FormatInt(model.AccountID.ValueInt64(), 10)
+}
+
+func shareGrantsFromSet(ctx context.Context, set types.Set, diags *diag.Diagnostics) []shareGrant {
+	var models []ShareGrantModel
+	diags.Append(set.ElementsAs(ctx, &models, false)...)
+	grants := make([]shareGrant, 0, len(models))
+	for _, model := range models {
+		grants = append(grants, shareGrant{
+			PrincipalType: model.PrincipalType.ValueString(),
+			PrincipalID:   model.PrincipalID.ValueInt64(),
+			Permission:    model.Permission.ValueString(),
+		})
+	}
+	return grants
+}
+
+// shareGrantsToSet converts the shares reported by the server, leaving out
+// unmanaged entries such as the owner so they never show up as drift.
+func shareGrantsToSet(grants []shareGrant, diags *diag.Diagnostics) types.Set {
+	sort.Slice(grants, func(i, j int) bool { return grants[i].key() < grants[j].key() })
+	elements := []attr.Value{}
+	for _, grant := range grants {
+		if !grant.managed() {
+			continue
+		}
+		element, elementDiags := types.ObjectValue(shareGrantAttrTypes, map[string]attr.Value{
+			"principal_type": types.StringValue(grant.PrincipalType),
+			"principal_id":   types.Int64Value(grant.PrincipalID),
+			"permission":     types.StringValue(grant.Permission),
+		})
+		diags.Append(elementDiags...)
+		elements = append(elements, element)
+	}
+	set, setDiags := types.SetValue(types.ObjectType{AttrTypes: shareGrantAttrTypes}, elements)
+	diags.Append(setDiags...)
+	return set
+}
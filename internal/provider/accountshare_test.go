@@ -0,0 +1,129 @@
+package provider
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func sharesServer(t *testing.T, shares ...map[string]any) *fakeServer {
+	return newFakeServer(t, map[string]fakeHandler{
+		"/secretsmanagement/get_shares": func(params map[string]any) any {
+			return map[string]any{"status_code": 200, "shares": shares}
+		},
+		"/api/share": func(params map[string]any) any {
+			return map[string]any{"status_code": 200, "message": "Shared"}
+		},
+		"/api/unshare": func(params map[string]any) any {
+			return map[string]any{"status_code": 200, "message": "Unshared"}
+		},
+	})
+}
+
+func testShareModel(t *testing.T, grants ...shareGrant) AccountShareResourceModel {
+	var diags diag.Diagnostics
+	model := AccountShareResourceModel{
+		ID:        types.StringUnknown(),
+		AccountID: types.Int64Value(2000000001800),
+		Grants:    shareGrantsToSet(grants, &diags),
+	}
+	if diags.HasError() {
+		t.Fatal(diags)
+	}
+	return model
+}
+
+func TestAccountShareResourceCreate(t *testing.T) {
+	ctx := context.Background()
+	s := resourceSchema(t, &AccountShareResource{})
+	owner := map[string]any{"principal_type": "user", "principal_id": 1, "permission": "Owner"}
+	auditors := map[string]any{"principal_type": "user_group", "principal_id": 120, "permission": "View"}
+	plan := testShareModel(t,
+		shareGrant{PrincipalType: "user_group", PrincipalID: 120, Permission: "view"},
+		shareGrant{PrincipalType: "user", PrincipalID: 7, Permission: "manage"},
+	)
+
+	t.Run("existing shares listed", func(t *testing.T) {
+		fake := sharesServer(t, owner, auditors)
+		resp := resource.CreateResponse{State: tfsdk.State{Schema: s}}
+		(&AccountShareResource{}).Create(ctx, resource.CreateRequest{Plan: newPlan(t, s, &plan)}, &resp)
+		if resp.Diagnostics.HasError() {
+			t.Fatal(resp.Diagnostics)
+		}
+		shares := fake.calls("/api/share")
+		if len(shares) != 1 || len(shares[0]["shares"].([]any)) != 1 {
+			t.Fatalf("share calls = %v, want only the new grant", shares)
+		}
+		if unshares := fake.calls("/api/unshare"); len(unshares) != 0 {
+			t.Errorf("unshare calls = %v, want none", unshares)
+		}
+		var got AccountShareResourceModel
+		getState(t, resp.State, &got)
+		if got.ID.ValueString() != "account:2000000001800" {
+			t.Errorf("id = %s", got.ID)
+		}
+	})
+
+	t.Run("existing shares not listed", func(t *testing.T) {
+		fake := sharesServer(t, owner, auditors, map[string]any{"principal_type": "user", "principal_id": 12, "permission": "Modify"})
+		resp := resource.CreateResponse{State: tfsdk.State{Schema: s}}
+		(&AccountShareResource{}).Create(ctx, resource.CreateRequest{Plan: newPlan(t, s, &plan)}, &resp)
+		if !resp.Diagnostics.HasError() {
+			t.Fatal("expected an error for existing shares")
+		}
+		detail := resp.Diagnostics.Errors()[0].Detail()
+		if !strings.Contains(detail, "user 12 (modify)") || strings.Contains(detail, "user 1 ") || !strings.Contains(detail, `"account:2000000001800"`) {
+			t.Errorf("error detail = %q", detail)
+		}
+		if calls := len(fake.calls("/api/share")) + len(fake.calls("/api/unshare")); calls != 0 {
+			t.Errorf("%d share changes sent, want none", calls)
+		}
+	})
+}
+
+func TestAccountShareResourceUpdate(t *testing.T) {
+	ctx := context.Background()
+	s := resourceSchema(t, &AccountShareResource{})
+	state := testShareModel(t,
+		shareGrant{PrincipalType: "user_group", PrincipalID: 120, Permission: "view"},
+		shareGrant{PrincipalType: "user", PrincipalID: 7, Permission: "manage"},
+		shareGrant{PrincipalType: "user", PrincipalID: 12, Permission: "view"},
+	)
+	state.ID = types.StringValue("account:2000000001800")
+	plan := testShareModel(t,
+		shareGrant{PrincipalType: "user_group", PrincipalID: 120, Permission: "view"},
+		shareGrant{PrincipalType: "user", PrincipalID: 7, Permission: "modify"},
+	)
+	plan.ID = state.ID
+
+	fake := sharesServer(t)
+	resp := resource.UpdateResponse{State: tfsdk.State{Schema: s}}
+	(&AccountShareResource{}).Update(ctx, resource.UpdateRequest{
+		Plan:  newPlan(t, s, &plan),
+		State: newState(t, s, &state),
+	}, &resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatal(resp.Diagnostics)
+	}
+	unshares := fake.calls("/api/unshare")
+	if len(unshares) != 1 {
+		t.Fatalf("unshare calls = %v, want one", unshares)
+	}
+	revoked := unshares[0]["shares"].([]any)
+	if len(revoked) != 1 || stringifyValue(revoked[0].(map[string]any)["principal_id"]) != "12" {
+		t.Errorf("revoked = %v, want user 12", revoked)
+	}
+	shares := fake.calls("/api/share")
+	if len(shares) != 1 {
+		t.Fatalf("share calls = %v, want one", shares)
+	}
+	granted := shares[0]["shares"].([]any)
+	if len(granted) != 1 || granted[0].(map[string]any)["permission"] != "modify" {
+		t.Errorf("granted = %v, want the changed permission of user 7 only", granted)
+	}
+}
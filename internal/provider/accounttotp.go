@@ -0,0 +1,168 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ ephemeral.EphemeralResource = &AccountTOTP{}
+var _ ephemeral.EphemeralResourceWithConfigure = &AccountTOTP{}
+
+func account_totp() ephemeral.EphemeralResource {
+	return &AccountTOTP{}
+}
+
+type AccountTOTP struct {
+	client *http.Client
+}
+
+type AccountTOTPModel struct {
+	AccountID    types.Int64  `tfsdk:"account_id"`
+	AccountName  types.String `tfsdk:"account_name"`
+	AccountTitle types.String `tfsdk:"account_title"`
+	TicketID     types.String `tfsdk:"ticket_id"`
+	Reason       types.String `tfsdk:"reason"`
+	TOTPField    types.String `tfsdk:"totp_field"`
+	Digits       types.Int64  `tfsdk:"digits"`
+	Period       types.Int64  `tfsdk:"period"`
+	Algorithm    types.String `tfsdk:"algorithm"`
+	Code         types.String `tfsdk:"code"`
+	ExpiresAt    types.String `tfsdk:"expires_at"`
+}
+
+func (d *AccountTOTP) Metadata(ctx context.Context, req ephemeral.MetadataRequest, resp *ephemeral.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_account_totp"
+}
+
+func (d *AccountTOTP) Schema(ctx context.Context, req ephemeral.SchemaRequest, resp *ephemeral.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Generates the current time-based one-time password (RFC 6238) from a TOTP seed stored in a Securden account field.",
+
+		Attributes: map[string]schema.Attribute{
+			"account_id": schema.Int64Attribute{
+				Optional:            true,
+				MarkdownDescription: "Unique identifier of the account.",
+			},
+			"account_name": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "The name associated with the account.",
+			},
+			"account_title": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Title or designation of the account.",
+			},
+			"ticket_id": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Ticket ID to be used for fetching the account.",
+			},
+			"reason": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Reason for fetching account.",
+			},
+			"totp_field": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Name of the account field holding the base32 TOTP seed or `otpauth://totp/` URI.",
+			},
+			"digits": schema.Int64Attribute{
+				Optional:            true,
+				MarkdownDescription: "Number of digits of the code. Defaults to the `otpauth` URI value or 6.",
+			},
+			"period": schema.Int64Attribute{
+				Optional:            true,
+				MarkdownDescription: "Time step in seconds. Defaults to the `otpauth` URI value or 30.",
+			},
+			"algorithm": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "HMAC algorithm: SHA1, SHA256 or SHA512. Defaults to the `otpauth` URI value or SHA1.",
+			},
+			"code": schema.StringAttribute{
+				Computed:            true,
+				Sensitive:           true,
+				MarkdownDescription: "The current one-time password.",
+			},
+			"expires_at": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "RFC 3339 timestamp at which the code stops being valid.",
+			},
+		},
+	}
+}
+
+func (d *AccountTOTP) Configure(ctx context.Context, req ephemeral.ConfigureRequest, resp *ephemeral.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	client, ok := req.ProviderData.(*http.Client)
+	if !ok {
+		resp.Diagnostics.AddWarning(
+			"Unexpected Ephemeral Resource Configure Type",
+			fmt.Sprintf("Expected *http.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+	d.client = client
+}
+
+func (d *AccountTOTP) Open(ctx context.Context, req ephemeral.OpenRequest, resp *ephemeral.OpenResponse) {
+	var data AccountTOTPModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	var account_id int64
+	if !data.AccountID.IsNull() {
+		account_id = data.AccountID.ValueInt64()
+	}
+	account_name := data.AccountName.ValueString()
+	account_title := data.AccountTitle.ValueString()
+	if account_id == 0 && account_name == "" && account_title == "" {
+		resp.Diagnostics.AddError(
+			"Invalid Input",
+			"At least one of account_id, account_name, or account_title must be provided.",
+		)
+		return
+	}
+	account, code, message := get_account(ctx, account_id, account_name, account_title, "", data.TicketID.ValueString(), data.Reason.ValueString())
+	if code != 200 {
+		resp.Diagnostics.AddError(fmt.Sprintf("%d - %s", code, message), "")
+		return
+	}
+	field := data.TOTPField.ValueString()
+	seed, ok := account.Account.Elements()[field].(types.String)
+	if !ok || seed.ValueString() == "" {
+		resp.Diagnostics.AddError(
+			"TOTP Field Not Found",
+			fmt.Sprintf("The account has no value for the field %q.", field),
+		)
+		return
+	}
+	config, err := parseTOTPSecret(seed.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid TOTP Secret", fmt.Sprintf("The field %q does not hold a valid TOTP secret: %v", field, err))
+		return
+	}
+	if !data.Digits.IsNull() {
+		config.Digits = int(data.Digits.ValueInt64())
+	}
+	if !data.Period.IsNull() {
+		config.Period = data.Period.ValueInt64()
+	}
+	if !data.Algorithm.IsNull() {
+		config.Algorithm = data.Algorithm.ValueString()
+	}
+	now := time.Now()
+	otp, err := generateTOTP(config, now)
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to Generate TOTP", err.Error())
+		return
+	}
+	data.Code = types.StringValue(otp)
+	data.ExpiresAt = types.StringValue(totpExpiry(config, now).Format(time.RFC3339))
+	resp.Diagnostics.Append(resp.Result.Set(ctx, &data)...)
+}
@@ -0,0 +1,205 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ datasource.DataSource = &AccountTypes{}
+
+func account_types() datasource.DataSource {
+	return &AccountTypes{}
+}
+
+type AccountTypes struct {
+	client *http.Client
+}
+
+type AccountTypesModel struct {
+	MaxResults   types.Int64        `tfsdk:"max_results"`
+	Names        []types.String     `tfsdk:"names"`
+	AccountTypes []AccountTypeModel `tfsdk:"account_types"`
+}
+
+type AccountTypeModel struct {
+	Name     types.String            `tfsdk:"name"`
+	Category types.String            `tfsdk:"category"`
+	Fields   []AccountTypeFieldModel `tfsdk:"fields"`
+}
+
+type AccountTypeFieldModel struct {
+	Name      types.String `tfsdk:"name"`
+	Label     types.String `tfsdk:"label"`
+	Required  types.Bool   `tfsdk:"required"`
+	Sensitive types.Bool   `tfsdk:"sensitive"`
+}
+
+// accountTypeEntry is an account type as returned by get_account_types.
+type accountTypeEntry struct {
+	Name     string
+	Category string
+	Fields   []accountTypeField
+}
+
+type accountTypeField struct {
+	Name      string
+	Label     string
+	Required  bool
+	Sensitive bool
+}
+
+func (d *AccountTypes) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_account_types"
+}
+
+func (d *AccountTypes) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Lists the account types configured on the Securden server and the fields of each type.",
+
+		Attributes: map[string]schema.Attribute{
+			"max_results": maxResultsAttribute("Maximum number of account types to return, the first ones in name order. Defaults to no limit."),
+			"names": schema.ListAttribute{
+				ElementType:         types.StringType,
+				Computed:            true,
+				MarkdownDescription: "Names of the account types, usable as `account_type`.",
+			},
+			"account_types": schema.ListNestedAttribute{
+				Computed:            true,
+				MarkdownDescription: "The account types and their fields.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"name": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Name of the account type.",
+						},
+						"category": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Category the account type belongs to, for example `Databases`.",
+						},
+						"fields": schema.ListNestedAttribute{
+							Computed:            true,
+							MarkdownDescription: "Fields of the account type.",
+							NestedObject: schema.NestedAttributeObject{
+								Attributes: map[string]schema.Attribute{
+									"name": schema.StringAttribute{
+										Computed:            true,
+										MarkdownDescription: "API name of the field.",
+									},
+									"label": schema.StringAttribute{
+										Computed:            true,
+										MarkdownDescription: "Display label of the field.",
+									},
+									"required": schema.BoolAttribute{
+										Computed:            true,
+										MarkdownDescription: "Whether the field must be set when adding an account of this type.",
+									},
+									"sensitive": schema.BoolAttribute{
+										Computed:            true,
+										MarkdownDescription: "Whether the field holds a secret.",
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *AccountTypes) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	client, ok := req.ProviderData.(*http.Client)
+	if !ok {
+		resp.Diagnostics.AddWarning(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *http.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+	d.client = client
+}
+
+func (d *AccountTypes) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data AccountTypesModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	// The result is sorted by name, so the whole listing is read and the cap
+	// is applied to the sorted types.
+	entries, code, message := get_account_types(ctx)
+	if code != 200 {
+		resp.Diagnostics.AddError(fmt.Sprintf("%d - %s", code, message), "")
+		return
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Name < entries[j].Name })
+	if maxResults := int(data.MaxResults.ValueInt64()); maxResults > 0 && len(entries) > maxResults {
+		entries = entries[:maxResults]
+	}
+	data.Names = []types.String{}
+	data.AccountTypes = []AccountTypeModel{}
+	for _, entry := range entries {
+		accountType := AccountTypeModel{
+			Name:     types.StringValue(entry.Name),
+			Category: types.StringValue(entry.Category),
+			Fields:   []AccountTypeFieldModel{},
+		}
+		for _, field := range entry.Fields {
+			accountType.Fields = append(accountType.Fields, AccountTypeFieldModel{
+				Name:      types.StringValue(field.Name),
+				Label:     types.StringValue(field.Label),
+				Required:  types.BoolValue(field.Required),
+				Sensitive: types.BoolValue(field.Sensitive),
+			})
+		}
+		data.Names = append(data.Names, accountType.Name)
+		data.AccountTypes = append(data.AccountTypes, accountType)
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// normalizeAccountType reduces an account type name to lower case letters and
+// digits without a trailing "account", so "LDAP Domain Account", "ldap_domain"
+// and "LDAP-Domain" all match the same entry.
+func normalizeAccountType(accountType string) string {
+	var builder strings.Builder
+	for _, r := range strings.ToLower(accountType) {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			builder.WriteRune(r)
+		}
+	}
+	return strings.TrimSuffix(builder.String(), "account")
+}
+
+// validateAccountTypeFields reports the type-specific attributes that
+// accountType requires but the configuration leaves unset. Unknown values are
+// accepted since they are only resolved at apply time.
+func validateAccountTypeFields(accountType types.String, values map[string]types.String, diags *diag.Diagnostics) {
+	if accountType.IsNull() || accountType.IsUnknown() {
+		return
+	}
+	for _, field := range accountTypeRequiredFields[normalizeAccountType(accountType.ValueString())] {
+		value, ok := values[field]
+		if !ok || value.IsUnknown() || value.ValueString() != "" {
+			continue
+		}
+		diags.AddAttributeError(
+			path.Root(field),
+			"Missing Attribute",
+			fmt.Sprintf("%s is required for %s accounts.", field, accountType.ValueString()),
+		)
+	}
+}
@@ -0,0 +1,89 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestNormalizeAccountType(t *testing.T) {
+	tests := map[string]string{
+		"LDAP Domain Account": "ldapdomain",
+		"ldap_domain":         "ldapdomain",
+		"LDAP-Domain":         "ldapdomain",
+		"AWS IAM":             "awsiam",
+		"Google Workspace":    "googleworkspace",
+		"Linux Account":       "linux",
+		"Windows 2019":        "windows2019",
+		"":                    "",
+	}
+	for accountType, want := range tests {
+		if got := normalizeAccountType(accountType); got != want {
+			t.Errorf("normalizeAccountType(%q) = %q, want %q", accountType, got, want)
+		}
+	}
+}
+
+func TestValidateAccountTypeFields(t *testing.T) {
+	tests := []struct {
+		name        string
+		accountType types.String
+		values      map[string]types.String
+		wantErrors  int
+	}{
+		{
+			name:        "missing distinguished name",
+			accountType: types.StringValue("LDAP Domain Account"),
+			values:      map[string]types.String{"distinguished_name": types.StringNull()},
+			wantErrors:  1,
+		},
+		{
+			name:        "distinguished name set",
+			accountType: types.StringValue("ldap_domain"),
+			values:      map[string]types.String{"distinguished_name": types.StringValue("CN=svc,DC=example,DC=com")},
+		},
+		{
+			name:        "unknown value",
+			accountType: types.StringValue("AWS IAM"),
+			values:      map[string]types.String{"account_alias": types.StringUnknown()},
+		},
+		{
+			name:        "type without required fields",
+			accountType: types.StringValue("Linux Account"),
+			values:      map[string]types.String{"domain_name": types.StringNull()},
+		},
+		{
+			name:        "unknown type",
+			accountType: types.StringUnknown(),
+			values:      map[string]types.String{"domain_name": types.StringNull()},
+		},
+	}
+	for _, test := range tests {
+		var diags diag.Diagnostics
+		validateAccountTypeFields(test.accountType, test.values, &diags)
+		if got := diags.ErrorsCount(); got != test.wantErrors {
+			t.Errorf("%s: got %d errors, want %d: %v", test.name, got, test.wantErrors, diags)
+		}
+	}
+}
+
+func TestAccountTypesReadMaxResults(t *testing.T) {
+	newFakeServer(t, map[string]fakeHandler{
+		"/secretsmanagement/get_account_types": func(params map[string]any) any {
+			return map[string]any{"status_code": 200, "account_types": []map[string]any{
+				{"type_name": "Windows", "category": "Server"},
+				{"type_name": "MySQL", "category": "Database"},
+				{"type_name": "Linux", "category": "Server"},
+				{"type_name": "AWS IAM", "category": "Cloud"},
+			}}
+		},
+	})
+	var got AccountTypesModel
+	if diags := readDataSource(t, &AccountTypes{}, &AccountTypesModel{MaxResults: types.Int64Value(2)}, &got); diags.HasError() {
+		t.Fatal(diags)
+	}
+	if len(got.Names) != 2 || got.Names[0].ValueString() != "AWS IAM" || got.Names[1].ValueString() != "Linux" {
+		t.Errorf("names = %v, want the first two in name order", got.Names)
+	}
+}
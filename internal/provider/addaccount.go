@@ -5,12 +5,18 @@ import (
 	"fmt"
 	"net/http"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/datasourcevalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
 var _ datasource.DataSource = &AddAccount{}
+var _ datasource.DataSourceWithValidateConfig = &AddAccount{}
+var _ datasource.DataSourceWithConfigValidators = &AddAccount{}
 
 func add_account() datasource.DataSource {
 	return &AddAccount{}
@@ -21,21 +27,24 @@ type AddAccount struct {
 }
 
 type AddAccountModel struct {
-	AccountName           types.String `tfsdk:"account_name"`
-	AccountTitle          types.String `tfsdk:"account_title"`
-	AccountType           types.String `tfsdk:"account_type"`
-	IPAddress             types.String `tfsdk:"ipaddress"`
-	Notes                 types.String `tfsdk:"notes"`
-	Tags                  types.String `tfsdk:"tags"`
-	PersonalAccount       types.Bool   `tfsdk:"personal_account"`
-	FolderID              types.Int64  `tfsdk:"folder_id"`
-	Password              types.String `tfsdk:"password"`
-	AccountExpirationDate types.String `tfsdk:"account_expiration_date"`
-	DistinguishedName     types.String `tfsdk:"distinguished_name"`
-	AccountAlias          types.String `tfsdk:"account_alias"`
-	DomainName            types.String `tfsdk:"domain_name"`
-	Message               types.String `tfsdk:"message"`
-	ID                    types.Int64  `tfsdk:"id"`
+	AccountName               types.String `tfsdk:"account_name"`
+	AccountTitle              types.String `tfsdk:"account_title"`
+	AccountType               types.String `tfsdk:"account_type"`
+	IPAddress                 types.String `tfsdk:"ipaddress"`
+	Notes                     types.String `tfsdk:"notes"`
+	Tags                      types.Set    `tfsdk:"tags"`
+	PersonalAccount           types.Bool   `tfsdk:"personal_account"`
+	AdditionalFields          types.Map    `tfsdk:"additional_fields"`
+	SensitiveAdditionalFields types.Map    `tfsdk:"sensitive_additional_fields"`
+	FolderID                  types.Int64  `tfsdk:"folder_id"`
+	FolderPath                types.String `tfsdk:"folder_path"`
+	Password                  types.String `tfsdk:"password"`
+	AccountExpirationDate     types.String `tfsdk:"account_expiration_date"`
+	DistinguishedName         types.String `tfsdk:"distinguished_name"`
+	AccountAlias              types.String `tfsdk:"account_alias"`
+	DomainName                types.String `tfsdk:"domain_name"`
+	Message                   types.String `tfsdk:"message"`
+	ID                        types.Int64  `tfsdk:"id"`
 }
 
 func (d *AddAccount) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
@@ -50,6 +59,9 @@ func (d *AddAccount) Schema(ctx context.Context, req datasource.SchemaRequest, r
 			"account_title": schema.StringAttribute{
 				MarkdownDescription: "The title associated with the account.",
 				Required:            true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
 			},
 			"account_name": schema.StringAttribute{
 				MarkdownDescription: "The name associated with the account.",
@@ -58,10 +70,14 @@ func (d *AddAccount) Schema(ctx context.Context, req datasource.SchemaRequest, r
 			"account_type": schema.StringAttribute{
 				MarkdownDescription: "Specifies the type or category of the account.",
 				Required:            true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
 			},
 			"password": schema.StringAttribute{
 				MarkdownDescription: "The password associated with the account.",
 				Optional:            true,
+				Sensitive:           true,
 			},
 			"personal_account": schema.BoolAttribute{
 				MarkdownDescription: "Indicates whether the account is personal (true/false).",
@@ -70,22 +86,45 @@ func (d *AddAccount) Schema(ctx context.Context, req datasource.SchemaRequest, r
 			"ipaddress": schema.StringAttribute{
 				MarkdownDescription: "The IP address of the account (if applicable).",
 				Optional:            true,
+				Validators: []validator.String{
+					hostAddress(),
+				},
 			},
 			"folder_id": schema.Int64Attribute{
 				MarkdownDescription: "The ID of the folder where the account is stored.",
 				Optional:            true,
 			},
+			"folder_path": schema.StringAttribute{
+				MarkdownDescription: "Path of the folder where the account is stored, for example `Prod/Databases/Oracle`. Resolved to a folder ID when the account is saved. Conflicts with `folder_id`.",
+				Optional:            true,
+			},
 			"notes": schema.StringAttribute{
 				MarkdownDescription: "Additional notes related to the account.",
 				Optional:            true,
 			},
-			"tags": schema.StringAttribute{
+			"tags": schema.SetAttribute{
+				ElementType:         types.StringType,
 				MarkdownDescription: "Tags associated with the account.",
 				Optional:            true,
+				Validators:          tagsValidators(),
+			},
+			"additional_fields": schema.MapAttribute{
+				ElementType:         types.StringType,
+				MarkdownDescription: "Additional (custom) fields of the account, such as an owner or a cost center, keyed by field name.",
+				Optional:            true,
+			},
+			"sensitive_additional_fields": schema.MapAttribute{
+				ElementType:         types.StringType,
+				MarkdownDescription: "Additional (custom) fields of the account holding secret values, keyed by field name. A field name cannot be set in both `additional_fields` and `sensitive_additional_fields`.",
+				Optional:            true,
+				Sensitive:           true,
 			},
 			"account_expiration_date": schema.StringAttribute{
-				MarkdownDescription: "The expiration date of the account (format: DD/MM/YYYY).",
+				MarkdownDescription: "The expiration date of the account, as a `DD/MM/YYYY` date, an RFC 3339 timestamp or a duration relative to apply time such as `+90d` or `+12w`. Sent to Securden as `DD/MM/YYYY`.",
 				Optional:            true,
+				Validators: []validator.String{
+					expirationDate(),
+				},
 			},
 			"distinguished_name": schema.StringAttribute{
 				MarkdownDescription: "Required for LDAP domain accounts.",
@@ -126,6 +165,26 @@ func (d *AddAccount) Configure(ctx context.Context, req datasource.ConfigureRequ
 	d.client = client
 }
 
+func (d *AddAccount) ConfigValidators(ctx context.Context) []datasource.ConfigValidator {
+	return []datasource.ConfigValidator{
+		datasourcevalidator.Conflicting(path.MatchRoot("folder_id"), path.MatchRoot("folder_path")),
+	}
+}
+
+func (d *AddAccount) ValidateConfig(ctx context.Context, req datasource.ValidateConfigRequest, resp *datasource.ValidateConfigResponse) {
+	var config AddAccountModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	validateAccountTypeFields(config.AccountType, map[string]types.String{
+		"distinguished_name": config.DistinguishedName,
+		"account_alias":      config.AccountAlias,
+		"domain_name":        config.DomainName,
+	}, &resp.Diagnostics)
+	validateAdditionalFields(ctx, config.AdditionalFields, config.SensitiveAdditionalFields, &resp.Diagnostics)
+}
+
 func (d *AddAccount) Create(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
 	var account AddAccountModel
 	resp.Diagnostics.Append(req.Config.Get(ctx, &account)...)
@@ -135,19 +194,29 @@ func (d *AddAccount) Create(ctx context.Context, req datasource.ReadRequest, res
 	setParam(params, "account_type", account.AccountType)
 	setParam(params, "ipaddress", account.IPAddress)
 	setParam(params, "notes", account.Notes)
-	setParam(params, "tags", account.Tags)
+	if tags := tagsFromSet(ctx, account.Tags, &resp.Diagnostics); tags != nil {
+		setTagsParam(params, tags)
+	}
 	setParam(params, "personal_account", account.PersonalAccount)
 	setParam(params, "folder_id", account.FolderID)
+	setFolderParam(ctx, params, account.FolderPath, &resp.Diagnostics)
 	setParam(params, "password", account.Password)
-	setParam(params, "account_expiration_date", account.AccountExpirationDate)
+	setExpirationParam(params, account.AccountExpirationDate, &resp.Diagnostics)
 	setParam(params, "distinguished_name", account.DistinguishedName)
 	setParam(params, "account_alias", account.AccountAlias)
 	setParam(params, "domain_name", account.DomainName)
+	setAdditionalFieldsParam(ctx, params, account.AdditionalFields, account.SensitiveAdditionalFields, nil, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 	added_account, code, message := add_account_function(ctx, params)
 	if code != 200 && code != 0 {
 		resp.Diagnostics.AddWarning(fmt.Sprintf("%d - %s", code, message), "")
 		return
 	}
+	added_account.Tags = account.Tags
+	added_account.AdditionalFields = account.AdditionalFields
+	added_account.SensitiveAdditionalFields = account.SensitiveAdditionalFields
 	resp.Diagnostics.Append(resp.State.Set(ctx, &added_account)...)
 }
 
@@ -160,18 +229,28 @@ func (d *AddAccount) Read(ctx context.Context, req datasource.ReadRequest, resp
 	setParam(params, "account_type", account.AccountType)
 	setParam(params, "ipaddress", account.IPAddress)
 	setParam(params, "notes", account.Notes)
-	setParam(params, "tags", account.Tags)
+	if tags := tagsFromSet(ctx, account.Tags, &resp.Diagnostics); tags != nil {
+		setTagsParam(params, tags)
+	}
 	setParam(params, "personal_account", account.PersonalAccount)
 	setParam(params, "folder_id", account.FolderID)
+	setFolderParam(ctx, params, account.FolderPath, &resp.Diagnostics)
 	setParam(params, "password", account.Password)
-	setParam(params, "account_expiration_date", account.AccountExpirationDate)
+	setExpirationParam(params, account.AccountExpirationDate, &resp.Diagnostics)
 	setParam(params, "distinguished_name", account.DistinguishedName)
 	setParam(params, "account_alias", account.AccountAlias)
 	setParam(params, "domain_name", account.DomainName)
+	setAdditionalFieldsParam(ctx, params, account.AdditionalFields, account.SensitiveAdditionalFields, nil, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 	added_account, code, message := add_account_function(ctx, params)
 	if code != 200 && code != 0 {
 		resp.Diagnostics.AddWarning(fmt.Sprintf("%d - %s", code, message), "")
 		return
 	}
+	added_account.Tags = account.Tags
+	added_account.AdditionalFields = account.AdditionalFields
+	added_account.SensitiveAdditionalFields = account.SensitiveAdditionalFields
 	resp.Diagnostics.Append(resp.State.Set(ctx, &added_account)...)
 }
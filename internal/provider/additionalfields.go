@@ -0,0 +1,121 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// additionalFieldsFromMap returns the fields of a map attribute, nil when the
+// attribute is not set.
+func additionalFieldsFromMap(ctx context.Context, fields types.Map, diags *diag.Diagnostics) map[string]string {
+	if fields.IsNull() || fields.IsUnknown() {
+		return nil
+	}
+	values := make(map[string]string)
+	diags.Append(fields.ElementsAs(ctx, &values, false)...)
+	return values
+}
+
+// validateAdditionalFields rejects field names that are set in both maps or
+// that shadow a standard account attribute.
+func validateAdditionalFields(ctx context.Context, fields, sensitiveFields types.Map, diags *diag.Diagnostics) {
+	plain := additionalFieldsFromMap(ctx, fields, diags)
+	sensitive := additionalFieldsFromMap(ctx, sensitiveFields, diags)
+	for _, attribute := range []struct {
+		name   string
+		fields map[string]string
+	}{{"additional_fields", plain}, {"sensitive_additional_fields", sensitive}} {
+		for _, name := range sortedKeys(attribute.fields) {
+			if _, standard := accountStandardFields[name]; standard {
+				diags.AddAttributeError(
+					path.Root(attribute.name).AtMapKey(name),
+					"Invalid Additional Field",
+					fmt.Sprintf("%q is a standard account attribute and cannot be set as an additional field.", name),
+				)
+			}
+		}
+	}
+	for _, name := range sortedKeys(sensitive) {
+		if _, exists := plain[name]; exists {
+			diags.AddAttributeError(
+				path.Root("sensitive_additional_fields").AtMapKey(name),
+				"Duplicate Additional Field",
+				fmt.Sprintf("%q is set in both additional_fields and sensitive_additional_fields.", name),
+			)
+		}
+	}
+}
+
+// setAdditionalFieldsParam sends the plain and sensitive additional fields as
+// a single additional_fields object. Fields in removed are sent empty so
+// Securden clears them.
+func setAdditionalFieldsParam(ctx context.Context, params map[string]any, fields, sensitiveFields types.Map, removed []string, diags *diag.Diagnostics) {
+	plain := additionalFieldsFromMap(ctx, fields, diags)
+	sensitive := additionalFieldsFromMap(ctx, sensitiveFields, diags)
+	if plain == nil && sensitive == nil && len(removed) == 0 {
+		return
+	}
+	values := make(map[string]string)
+	for _, name := range removed {
+		values[name] = ""
+	}
+	for name, value := range plain {
+		values[name] = value
+	}
+	for name, value := range sensitive {
+		values[name] = value
+	}
+	params["additional_fields"] = values
+}
+
+// removedAdditionalFields lists the fields managed in state that are no
+// longer in either planned map.
+func removedAdditionalFields(ctx context.Context, plan, state AccountResourceModel, diags *diag.Diagnostics) []string {
+	planned := make(map[string]struct{})
+	for _, fields := range []types.Map{plan.AdditionalFields, plan.SensitiveAdditionalFields} {
+		for name := range additionalFieldsFromMap(ctx, fields, diags) {
+			planned[name] = struct{}{}
+		}
+	}
+	var removed []string
+	for _, fields := range []types.Map{state.AdditionalFields, state.SensitiveAdditionalFields} {
+		for _, name := range sortedKeys(additionalFieldsFromMap(ctx, fields, diags)) {
+			if _, exists := planned[name]; !exists {
+				removed = append(removed, name)
+			}
+		}
+	}
+	return removed
+}
+
+// refreshAdditionalFields updates the managed fields with the values read
+// from Securden. Fields that are no longer set are dropped so the change is
+// reported as drift, fields that are not managed are ignored.
+func refreshAdditionalFields(ctx context.Context, current, remote types.Map, diags *diag.Diagnostics) types.Map {
+	if current.IsNull() || remote.IsNull() || remote.IsUnknown() {
+		return current
+	}
+	remoteFields := additionalFieldsFromMap(ctx, remote, diags)
+	values := make(map[string]attr.Value)
+	for name := range additionalFieldsFromMap(ctx, current, diags) {
+		if value, exists := remoteFields[name]; exists && value != "" {
+			values[name] = types.StringValue(value)
+		}
+	}
+	return types.MapValueMust(types.StringType, values)
+}
+
+func sortedKeys(values map[string]string) []string {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,95 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func stringMap(t *testing.T, values map[string]string) types.Map {
+	t.Helper()
+	fields, diags := types.MapValueFrom(context.Background(), types.StringType, values)
+	if diags.HasError() {
+		t.Fatal(diags)
+	}
+	return fields
+}
+
+func TestValidateAdditionalFields(t *testing.T) {
+	tests := []struct {
+		name       string
+		fields     types.Map
+		sensitive  types.Map
+		wantErrors int
+	}{
+		{"custom fields", stringMap(t, map[string]string{"owner": "dba"}), stringMap(t, map[string]string{"api_key": "k"}), 0},
+		{"standard field", stringMap(t, map[string]string{"password": "s3cret"}), types.MapNull(types.StringType), 1},
+		{"standard sensitive field", types.MapNull(types.StringType), stringMap(t, map[string]string{"private_key": "k"}), 1},
+		{"field in both maps", stringMap(t, map[string]string{"owner": "dba"}), stringMap(t, map[string]string{"owner": "dba"}), 1},
+	}
+	for _, test := range tests {
+		var diags diag.Diagnostics
+		validateAdditionalFields(context.Background(), test.fields, test.sensitive, &diags)
+		if got := diags.ErrorsCount(); got != test.wantErrors {
+			t.Errorf("%s: got %d errors, want %d: %v", test.name, got, test.wantErrors, diags)
+		}
+	}
+}
+
+func TestAccountResourceUpdateAdditionalFields(t *testing.T) {
+	ctx := context.Background()
+	s := resourceSchema(t, &AccountResource{})
+	fake := editAccountServer(t)
+	state := testAccountResourceModel()
+	state.AdditionalFields = stringMap(t, map[string]string{"owner": "dba", "cost_center": "cc-12"})
+	state.SensitiveAdditionalFields = stringMap(t, map[string]string{"api_key": "k1"})
+	plan := testAccountResourceModel()
+	plan.AdditionalFields = stringMap(t, map[string]string{"owner": "platform"})
+
+	resp := resource.UpdateResponse{State: tfsdk.State{Schema: s}}
+	(&AccountResource{}).Update(ctx, resource.UpdateRequest{
+		Plan:   newPlan(t, s, &plan),
+		State:  newState(t, s, &state),
+		Config: newConfig(t, s, &plan),
+	}, &resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatal(resp.Diagnostics)
+	}
+	calls := fake.calls("/api/edit_account")
+	if len(calls) != 1 {
+		t.Fatalf("edit_account calls = %v, want one", calls)
+	}
+	fields, _ := calls[0]["additional_fields"].(map[string]any)
+	want := map[string]string{"owner": "platform", "cost_center": "", "api_key": ""}
+	if len(fields) != len(want) {
+		t.Errorf("additional_fields = %v, want %v", fields, want)
+	}
+	for name, value := range want {
+		if fields[name] != value {
+			t.Errorf("additional field %s = %v, want %q", name, fields[name], value)
+		}
+	}
+}
+
+func TestAccountResourceReadAdditionalFields(t *testing.T) {
+	s := resourceSchema(t, &AccountResource{})
+	getAccountServer(t, map[string]any{
+		"account_id":        2000000001800,
+		"account_title":     "Orders DB",
+		"additional_fields": map[string]any{"owner": "platform", "rotation": "quarterly"},
+	})
+	state := testAccountResourceModel()
+	state.AdditionalFields = stringMap(t, map[string]string{"owner": "dba", "cost_center": "cc-12"})
+	got := readAccount(t, newState(t, s, &state))
+	want := stringMap(t, map[string]string{"owner": "platform"})
+	if !got.AdditionalFields.Equal(want) {
+		t.Errorf("additional_fields = %s, want %s", got.AdditionalFields, want)
+	}
+	if !got.SensitiveAdditionalFields.IsNull() {
+		t.Errorf("sensitive_additional_fields = %s, want null", got.SensitiveAdditionalFields)
+	}
+}
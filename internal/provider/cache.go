@@ -0,0 +1,143 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"golang.org/x/sync/singleflight"
+)
+
+// defaultCacheTTL is used when the provider block enables the cache without
+// setting cache_ttl.
+var defaultCacheTTL = 5 * time.Minute
+
+// SecurdenCache is the provider-scoped response cache, nil when disabled.
+var SecurdenCache *responseCache
+
+type cacheEntry struct {
+	body    []byte
+	expires time.Time
+}
+
+// responseCache keeps the bodies of read requests for the duration of a
+// Terraform run. Concurrent identical requests are collapsed into a single
+// HTTP round-trip.
+type responseCache struct {
+	mu      sync.Mutex
+	ttl     time.Duration
+	entries map[string]cacheEntry
+	group   singleflight.Group
+}
+
+func newResponseCache(ttl time.Duration) *responseCache {
+	return &responseCache{
+		ttl:     ttl,
+		entries: make(map[string]cacheEntry),
+	}
+}
+
+func (c *responseCache) get(key string) ([]byte, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	entry, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+	if time.Now().After(entry.expires) {
+		delete(c.entries, key)
+		return nil, false
+	}
+	return entry.body, true
+}
+
+func (c *responseCache) set(key string, body []byte) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries[key] = cacheEntry{body: body, expires: time.Now().Add(c.ttl)}
+}
+
+// clear drops every cached response. It is called after each write so later
+// reads in the same run see the change.
+func (c *responseCache) clear() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries = make(map[string]cacheEntry)
+}
+
+// invalidate_cache empties the provider cache when it is enabled.
+func invalidate_cache() {
+	if cache := SecurdenCache; cache != nil {
+		cache.clear()
+	}
+}
+
+// cacheable reports whether body is a successful response. Like the callers,
+// a response without a status_code is a success. Errors are shared with
+// concurrent callers but never stored.
+func cacheable(body []byte) bool {
+	var response map[string]any
+	if err := decodeJSON(body, &response); err != nil {
+		return false
+	}
+	statusCode, ok := int64Value(response["status_code"])
+	return !ok || statusCode == 200
+}
+
+func cacheKey(params map[string]any, apiURL string, method string) (string, error) {
+	normalized := make(map[string]any, len(params))
+	for key, value := range params {
+		if tfIDs, ok := value.([]types.Int64); ok {
+			ids := make([]int64, 0, len(tfIDs))
+			for _, id := range tfIDs {
+				ids = append(ids, id.ValueInt64())
+			}
+			value = ids
+		}
+		normalized[key] = value
+	}
+	// json.Marshal sorts map keys, so equal parameters give equal keys.
+	encoded, err := json.Marshal(normalized)
+	if err != nil {
+		return "", err
+	}
+	return method + " " + apiURL + " " + string(encoded), nil
+}
+
+// cached_request serves read requests from the provider cache when it is
+// enabled and falls back to raise_request otherwise. Only responses with a
+// status_code of 200 are cached.
+func cached_request(ctx context.Context, params map[string]any, apiURL string, method string) ([]byte, error) {
+	cache := SecurdenCache
+	if cache == nil {
+		return raise_request(ctx, params, apiURL, method)
+	}
+	key, err := cacheKey(params, apiURL, method)
+	if err != nil {
+		return raise_request(ctx, params, apiURL, method)
+	}
+	if body, ok := cache.get(key); ok {
+		return body, nil
+	}
+	body, err, _ := cache.group.Do(key, func() (interface{}, error) {
+		if body, ok := cache.get(key); ok {
+			return body, nil
+		}
+		// The shared call must not fail for every caller when the one that
+		// started it is canceled.
+		body, err := raise_request(context.WithoutCancel(ctx), params, apiURL, method)
+		if err != nil {
+			return nil, err
+		}
+		if cacheable(body) {
+			cache.set(key, body)
+		}
+		return body, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return body.([]byte), nil
+}
@@ -0,0 +1,105 @@
+package provider
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestCacheable(t *testing.T) {
+	tests := map[string]bool{
+		`{"status_code": 200, "account_id": 1}`: true,
+		`{"status_code": "200"}`:                true,
+		`{"status_code": 404, "message": "x"}`:  false,
+		`{"status_code": 500}`:                  false,
+		`{"account_id": 1}`:                     true,
+		`<html>Bad Gateway</html>`:              false,
+	}
+	for body, want := range tests {
+		if got := cacheable([]byte(body)); got != want {
+			t.Errorf("cacheable(%s) = %t, want %t", body, got, want)
+		}
+	}
+}
+
+func TestResponseCacheClear(t *testing.T) {
+	cache := newResponseCache(time.Minute)
+	cache.set("key", []byte("body"))
+	if _, ok := cache.get("key"); !ok {
+		t.Fatal("entry not cached")
+	}
+	cache.clear()
+	if _, ok := cache.get("key"); ok {
+		t.Error("entry still cached after clear")
+	}
+}
+
+func TestResponseCacheExpires(t *testing.T) {
+	cache := newResponseCache(-time.Second)
+	cache.set("key", []byte("body"))
+	if _, ok := cache.get("key"); ok {
+		t.Error("expired entry returned")
+	}
+}
+
+func TestCacheKey(t *testing.T) {
+	first, err := cacheKey(map[string]any{"account_id": int64(1), "reason": "audit"}, "/get_account", GET)
+	if err != nil {
+		t.Fatal(err)
+	}
+	second, err := cacheKey(map[string]any{"reason": "audit", "account_id": int64(1)}, "/get_account", GET)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if first != second {
+		t.Errorf("equal parameters gave different keys %q and %q", first, second)
+	}
+	ids, err := cacheKey(map[string]any{"account_ids": []types.Int64{types.Int64Value(2000000001800)}}, "/get_accounts", POST)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := `POST /get_accounts {"account_ids":[2000000001800]}`; ids != want {
+		t.Errorf("cacheKey = %q, want %q", ids, want)
+	}
+}
+
+func TestCachedRequestOutlivesCanceledCaller(t *testing.T) {
+	started, release := make(chan struct{}), make(chan struct{})
+	fake := newFakeServer(t, map[string]fakeHandler{
+		"/secretsmanagement/get_account": func(params map[string]any) any {
+			close(started)
+			<-release
+			return map[string]any{"account_id": 2000000001800, "account_title": "Orders DB"}
+		},
+	})
+	SecurdenCache = newResponseCache(time.Minute)
+	params := map[string]any{"account_id": int64(2000000001800)}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() {
+		_, err := cached_request(ctx, params, "/secretsmanagement/get_account", GET)
+		done <- err
+	}()
+	<-started
+	cancel()
+	close(release)
+	if err := <-done; err != nil {
+		t.Fatalf("shared request failed after its first caller was canceled: %v", err)
+	}
+
+	// The response has no status_code and is still cached.
+	body, err := cached_request(context.Background(), params, "/secretsmanagement/get_account", GET)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(body), "Orders DB") {
+		t.Errorf("body = %s", body)
+	}
+	if calls := len(fake.calls("/secretsmanagement/get_account")); calls != 1 {
+		t.Errorf("%d requests sent, want 1", calls)
+	}
+}
@@ -0,0 +1,58 @@
+package provider
+
+import (
+	"fmt"
+	"sync"
+)
+
+// SecurdenConnectivity tracks the lazy reachability probe of the configured
+// server, it is replaced every time the provider is configured.
+var SecurdenConnectivity = &connectivityState{}
+
+// connectivityState probes the server once, on the first request that needs
+// it, instead of during Configure. This lets a configuration create the
+// Securden server and consume it in the same run.
+type connectivityState struct {
+	once    sync.Once
+	skip    bool
+	err     error
+	offline bool
+}
+
+func newConnectivityState(skip bool) *connectivityState {
+	return &connectivityState{skip: skip}
+}
+
+// check returns an error when the server is not reachable. With an offline
+// cache configured the provider switches to offline mode instead, which is
+// why the probe is still made then even if it is skipped otherwise.
+func (c *connectivityState) check() error {
+	if c.skip && SecurdenOfflineCache == nil {
+		return nil
+	}
+	c.once.Do(func() {
+		if SecurdenServerURL == "" {
+			c.err = fmt.Errorf("the provider is not configured, server_url is unknown")
+			return
+		}
+		if isServerReachable(SecurdenServerURL) {
+			return
+		}
+		if SecurdenOfflineCache != nil {
+			c.offline = true
+			return
+		}
+		c.err = fmt.Errorf("the Securden server %s is not reachable", SecurdenServerURL)
+	})
+	return c.err
+}
+
+// isOfflineMode reports whether reads must be served from the offline cache,
+// probing the server on first use.
+func isOfflineMode() bool {
+	if SecurdenOfflineCache == nil {
+		return false
+	}
+	SecurdenConnectivity.check()
+	return SecurdenConnectivity.offline
+}
@@ -7,3 +7,48 @@ var POST = "POST"
 var GET = "GET"
 var PUT = "PUT"
 var DELETE = "DELETE"
+
+// accountStandardFields lists the attributes Securden returns for every
+// account type; any other field in a get_account response is treated as an
+// additional (custom) field.
+var accountStandardFields = map[string]struct{}{
+	"account_id":              {},
+	"account_name":            {},
+	"account_title":           {},
+	"account_type":            {},
+	"password":                {},
+	"key_value":               {},
+	"private_key":             {},
+	"putty_private_key":       {},
+	"passphrase":              {},
+	"ppk_passphrase":          {},
+	"address":                 {},
+	"client_id":               {},
+	"client_secret":           {},
+	"account_alias":           {},
+	"account_file":            {},
+	"default_database":        {},
+	"sql_server_port":         {},
+	"mysql_port":              {},
+	"oracle_sid":              {},
+	"oracle_service_name":     {},
+	"oracle_port":             {},
+	"port":                    {},
+	"tags":                    {},
+	"notes":                   {},
+	"folder_id":               {},
+	"ipaddress":               {},
+	"personal_account":        {},
+	"additional_fields":       {},
+	"account_expiration_date": {},
+	"distinguished_name":      {},
+	"domain_name":             {},
+}
+
+// accountTypeRequiredFields lists the type-specific attributes Securden
+// requires when adding an account, keyed by normalizeAccountType.
+var accountTypeRequiredFields = map[string][]string{
+	"ldapdomain":      {"distinguished_name"},
+	"awsiam":          {"account_alias"},
+	"googleworkspace": {"domain_name"},
+}
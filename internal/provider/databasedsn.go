@@ -0,0 +1,346 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ function.Function = &DatabaseDSN{}
+
+func database_dsn() function.Function {
+	return &DatabaseDSN{}
+}
+
+type DatabaseDSN struct{}
+
+type databaseEndpoint struct {
+	Engine      string
+	Host        string
+	Port        int
+	Database    string
+	User        string
+	Password    string
+	SID         string
+	ServiceName string
+}
+
+var databaseDefaultPorts = map[string]int{
+	"sqlserver":  1433,
+	"mysql":      3306,
+	"oracle":     1521,
+	"postgresql": 5432,
+}
+
+func (f *DatabaseDSN) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "database_dsn"
+}
+
+func (f *DatabaseDSN) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Builds a database connection string from a Securden account.",
+		MarkdownDescription: "Builds a properly escaped database connection string from the `account` map of a `securden_account` data source. " +
+			"Supported formats are `jdbc`, `adonet`, `libpq`, `mysql` and `ezconnect`. The `jdbc` format detects the database engine from the " +
+			"account attributes; use `jdbc:sqlserver`, `jdbc:mysql`, `jdbc:oracle` or `jdbc:postgresql` to choose it explicitly.",
+		Parameters: []function.Parameter{
+			function.MapParameter{
+				Name:                "account_map",
+				ElementType:         types.StringType,
+				MarkdownDescription: "The `account` map of a `securden_account` data source.",
+			},
+			function.StringParameter{
+				Name:                "format",
+				MarkdownDescription: "Connection string format: `jdbc`, `adonet`, `libpq`, `mysql` or `ezconnect`.",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *DatabaseDSN) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var account map[string]string
+	var format string
+	resp.Error = function.ConcatFuncErrors(resp.Error, req.Arguments.Get(ctx, &account, &format))
+	if resp.Error != nil {
+		return
+	}
+	format = strings.ToLower(strings.TrimSpace(format))
+	engine := ""
+	if strings.HasPrefix(format, "jdbc:") {
+		engine = strings.TrimPrefix(format, "jdbc:")
+		format = "jdbc"
+	}
+	switch format {
+	case "adonet":
+		engine = "sqlserver"
+	case "libpq":
+		engine = "postgresql"
+	case "mysql":
+		engine = "mysql"
+	case "ezconnect":
+		engine = "oracle"
+	case "jdbc":
+		if engine == "" {
+			engine = detectDatabaseEngine(account)
+		}
+		if engine == "" {
+			resp.Error = function.NewArgumentFuncError(0, "Unable to detect the database engine from the account attributes, use one of jdbc:sqlserver, jdbc:mysql, jdbc:oracle or jdbc:postgresql.")
+			return
+		}
+	default:
+		resp.Error = function.NewArgumentFuncError(1, fmt.Sprintf("Unsupported format %q, expected one of jdbc, adonet, libpq, mysql or ezconnect.", format))
+		return
+	}
+	if _, ok := databaseDefaultPorts[engine]; !ok {
+		resp.Error = function.NewArgumentFuncError(1, fmt.Sprintf("Unsupported database engine %q, expected one of sqlserver, mysql, oracle or postgresql.", engine))
+		return
+	}
+	endpoint, err := databaseEndpointFromAccount(account, engine)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, err.Error())
+		return
+	}
+	var dsn string
+	switch format {
+	case "jdbc":
+		dsn, err = jdbcDSN(endpoint)
+	case "adonet":
+		dsn = adoNetDSN(endpoint)
+	case "libpq":
+		dsn = libpqDSN(endpoint)
+	case "mysql":
+		dsn = mysqlDSN(endpoint)
+	case "ezconnect":
+		dsn, err = ezConnectDSN(endpoint)
+	}
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, err.Error())
+		return
+	}
+	resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, dsn))
+}
+
+func detectDatabaseEngine(account map[string]string) string {
+	switch {
+	case account["sql_server_port"] != "":
+		return "sqlserver"
+	case account["mysql_port"] != "":
+		return "mysql"
+	case account["oracle_port"] != "" || account["oracle_sid"] != "" || account["oracle_service_name"] != "":
+		return "oracle"
+	}
+	accountType := strings.ToLower(account["account_type"])
+	switch {
+	case strings.Contains(accountType, "sql server") || strings.Contains(accountType, "mssql"):
+		return "sqlserver"
+	case strings.Contains(accountType, "mysql") || strings.Contains(accountType, "mariadb"):
+		return "mysql"
+	case strings.Contains(accountType, "oracle"):
+		return "oracle"
+	case strings.Contains(accountType, "postgres"):
+		return "postgresql"
+	}
+	return ""
+}
+
+func databaseEndpointFromAccount(account map[string]string, engine string) (databaseEndpoint, error) {
+	endpoint := databaseEndpoint{
+		Engine:      engine,
+		Host:        strings.TrimSpace(account["address"]),
+		Port:        databaseDefaultPorts[engine],
+		Database:    account["default_database"],
+		User:        account["account_name"],
+		Password:    account["password"],
+		SID:         account["oracle_sid"],
+		ServiceName: account["oracle_service_name"],
+	}
+	if endpoint.Host == "" {
+		return endpoint, fmt.Errorf("the account has no address attribute to connect to")
+	}
+	portKey := map[string]string{
+		"sqlserver":  "sql_server_port",
+		"mysql":      "mysql_port",
+		"oracle":     "oracle_port",
+		"postgresql": "port",
+	}[engine]
+	portStr := strings.TrimSpace(account[portKey])
+	if portStr == "" {
+		portStr = strings.TrimSpace(account["port"])
+	}
+	if portStr != "" {
+		port, err := strconv.Atoi(portStr)
+		if err != nil || port < 1 || port > 65535 {
+			return endpoint, fmt.Errorf("invalid port %q in account attribute %s", portStr, portKey)
+		}
+		endpoint.Port = port
+	}
+	return endpoint, nil
+}
+
+func (e databaseEndpoint) hostPort() string {
+	return net.JoinHostPort(e.Host, strconv.Itoa(e.Port))
+}
+
+func jdbcDSN(e databaseEndpoint) (string, error) {
+	switch e.Engine {
+	case "sqlserver":
+		var b strings.Builder
+		b.WriteString("jdbc:sqlserver://")
+		b.WriteString(e.hostPort())
+		if e.Database != "" {
+			b.WriteString(";databaseName=" + jdbcSQLServerValue(e.Database))
+		}
+		if e.User != "" {
+			b.WriteString(";user=" + jdbcSQLServerValue(e.User))
+		}
+		if e.Password != "" {
+			b.WriteString(";password=" + jdbcSQLServerValue(e.Password))
+		}
+		return b.String(), nil
+	case "mysql", "postgresql":
+		u := url.URL{
+			Scheme: e.Engine,
+			Host:   e.hostPort(),
+			Path:   "/" + e.Database,
+		}
+		q := url.Values{}
+		if e.User != "" {
+			q.Set("user", e.User)
+		}
+		if e.Password != "" {
+			q.Set("password", e.Password)
+		}
+		u.RawQuery = q.Encode()
+		return "jdbc:" + u.String(), nil
+	case "oracle":
+		credentials, err := oracleCredentials(e)
+		if err != nil {
+			return "", err
+		}
+		if e.ServiceName != "" {
+			return "jdbc:oracle:thin:" + credentials + "@//" + e.hostPort() + "/" + e.ServiceName, nil
+		}
+		if e.SID != "" {
+			return "jdbc:oracle:thin:" + credentials + "@" + e.hostPort() + ":" + e.SID, nil
+		}
+		return "", fmt.Errorf("the account has neither oracle_service_name nor oracle_sid")
+	}
+	return "", fmt.Errorf("unsupported database engine %q", e.Engine)
+}
+
+// jdbcSQLServerValue wraps values containing connection string delimiters in
+// braces, doubling any closing brace as required by the SQL Server JDBC driver.
+func jdbcSQLServerValue(value string) string {
+	if !strings.ContainsAny(value, ";={} \t") {
+		return value
+	}
+	return "{" + strings.ReplaceAll(value, "}", "}}") + "}"
+}
+
+func adoNetDSN(e databaseEndpoint) string {
+	parts := []string{"Server=" + adoNetValue(e.Host+","+strconv.Itoa(e.Port))}
+	if e.Database != "" {
+		parts = append(parts, "Database="+adoNetValue(e.Database))
+	}
+	if e.User != "" {
+		parts = append(parts, "User Id="+adoNetValue(e.User))
+	}
+	if e.Password != "" {
+		parts = append(parts, "Password="+adoNetValue(e.Password))
+	}
+	return strings.Join(parts, ";") + ";"
+}
+
+// adoNetValue quotes values following the DbConnectionStringBuilder rules:
+// values with delimiters or surrounding whitespace are enclosed in quotes and
+// embedded quotes of the enclosing kind are doubled.
+func adoNetValue(value string) string {
+	if value == "" || (!strings.ContainsAny(value, ";'\"") && strings.TrimSpace(value) == value) {
+		return value
+	}
+	if strings.Contains(value, "\"") && !strings.Contains(value, "'") {
+		return "'" + value + "'"
+	}
+	return "\"" + strings.ReplaceAll(value, "\"", "\"\"") + "\""
+}
+
+func libpqDSN(e databaseEndpoint) string {
+	parts := []string{
+		"host=" + libpqValue(e.Host),
+		"port=" + strconv.Itoa(e.Port),
+	}
+	if e.Database != "" {
+		parts = append(parts, "dbname="+libpqValue(e.Database))
+	}
+	if e.User != "" {
+		parts = append(parts, "user="+libpqValue(e.User))
+	}
+	if e.Password != "" {
+		parts = append(parts, "password="+libpqValue(e.Password))
+	}
+	return strings.Join(parts, " ")
+}
+
+// libpqValue single-quotes values containing whitespace or quotes and escapes
+// backslashes and single quotes, as described for libpq keyword/value strings.
+func libpqValue(value string) string {
+	if value != "" && !strings.ContainsAny(value, " \t\n\r'\\") {
+		return value
+	}
+	escaped := strings.ReplaceAll(value, "\\", "\\\\")
+	escaped = strings.ReplaceAll(escaped, "'", "\\'")
+	return "'" + escaped + "'"
+}
+
+func mysqlDSN(e databaseEndpoint) string {
+	u := url.URL{
+		Scheme: "mysql",
+		Host:   e.hostPort(),
+	}
+	if e.Database != "" {
+		u.Path = "/" + e.Database
+	}
+	if e.User != "" {
+		if e.Password != "" {
+			u.User = url.UserPassword(e.User, e.Password)
+		} else {
+			u.User = url.User(e.User)
+		}
+	}
+	return u.String()
+}
+
+func ezConnectDSN(e databaseEndpoint) (string, error) {
+	if e.ServiceName == "" {
+		return "", fmt.Errorf("EZConnect requires the oracle_service_name attribute")
+	}
+	return "//" + e.hostPort() + "/" + e.ServiceName, nil
+}
+
+// oracleCredentials formats user/password for Oracle connect strings, quoting
+// the password when it contains characters outside the unquoted identifier set.
+func oracleCredentials(e databaseEndpoint) (string, error) {
+	if e.User == "" {
+		return "", nil
+	}
+	if e.Password == "" {
+		return e.User, nil
+	}
+	if strings.Contains(e.Password, "\"") {
+		return "", fmt.Errorf("Oracle passwords cannot contain double quotes")
+	}
+	password := e.Password
+	for _, r := range password {
+		if !(r == '_' || r == '$' || r == '#' || (r >= '0' && r <= '9') || (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z')) {
+			password = "\"" + password + "\""
+			break
+		}
+	}
+	return e.User + "/" + password, nil
+}
@@ -0,0 +1,198 @@
+package provider
+
+import "testing"
+
+func TestJDBCDSN(t *testing.T) {
+	tests := []struct {
+		name     string
+		endpoint databaseEndpoint
+		want     string
+	}{
+		{
+			name:     "sqlserver",
+			endpoint: databaseEndpoint{Engine: "sqlserver", Host: "db.example.com", Port: 1433, Database: "sales", User: "sa", Password: "p;w"},
+			want:     "jdbc:sqlserver://db.example.com:1433;databaseName=sales;user=sa;password={p;w}",
+		},
+		{
+			name:     "sqlserver closing brace",
+			endpoint: databaseEndpoint{Engine: "sqlserver", Host: "db", Port: 1433, Password: "a}b c"},
+			want:     "jdbc:sqlserver://db:1433;password={a}}b c}",
+		},
+		{
+			name:     "postgresql",
+			endpoint: databaseEndpoint{Engine: "postgresql", Host: "pg", Port: 5432, Database: "app", User: "u", Password: "p@ss"},
+			want:     "jdbc:postgresql://pg:5432/app?password=p%40ss&user=u",
+		},
+		{
+			name:     "mysql ipv6",
+			endpoint: databaseEndpoint{Engine: "mysql", Host: "::1", Port: 3306, Database: "app"},
+			want:     "jdbc:mysql://[::1]:3306/app",
+		},
+		{
+			name:     "oracle service name",
+			endpoint: databaseEndpoint{Engine: "oracle", Host: "ora", Port: 1521, User: "scott", Password: "tiger", ServiceName: "ORCL"},
+			want:     "jdbc:oracle:thin:scott/tiger@//ora:1521/ORCL",
+		},
+		{
+			name:     "oracle sid quoted password",
+			endpoint: databaseEndpoint{Engine: "oracle", Host: "ora", Port: 1521, User: "scott", Password: "p w", SID: "XE"},
+			want:     "jdbc:oracle:thin:scott/\"p w\"@ora:1521:XE",
+		},
+	}
+	for _, test := range tests {
+		got, err := jdbcDSN(test.endpoint)
+		if err != nil {
+			t.Fatalf("%s: %v", test.name, err)
+		}
+		if got != test.want {
+			t.Errorf("%s: got %q, want %q", test.name, got, test.want)
+		}
+	}
+}
+
+func TestJDBCDSNErrors(t *testing.T) {
+	tests := map[string]databaseEndpoint{
+		"oracle without service": {Engine: "oracle", Host: "ora", Port: 1521},
+		"oracle quoted password": {Engine: "oracle", Host: "ora", Port: 1521, User: "scott", Password: "a\"b", SID: "XE"},
+		"unsupported engine":     {Engine: "db2", Host: "db", Port: 50000},
+	}
+	for name, endpoint := range tests {
+		if _, err := jdbcDSN(endpoint); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
+
+func TestAdoNetDSN(t *testing.T) {
+	tests := []struct {
+		endpoint databaseEndpoint
+		want     string
+	}{
+		{
+			endpoint: databaseEndpoint{Host: "sql", Port: 1433, Database: "sales", User: "sa", Password: "a;b"},
+			want:     `Server=sql,1433;Database=sales;User Id=sa;Password="a;b";`,
+		},
+		{
+			endpoint: databaseEndpoint{Host: "sql", Port: 1433, Password: `say "hi"`},
+			want:     `Server=sql,1433;Password='say "hi"';`,
+		},
+		{
+			endpoint: databaseEndpoint{Host: "sql", Port: 1433, Password: `it's "x"`},
+			want:     `Server=sql,1433;Password="it's ""x""";`,
+		},
+		{
+			endpoint: databaseEndpoint{Host: "sql", Port: 1433, User: " sa"},
+			want:     `Server=sql,1433;User Id=" sa";`,
+		},
+	}
+	for _, test := range tests {
+		if got := adoNetDSN(test.endpoint); got != test.want {
+			t.Errorf("got %q, want %q", got, test.want)
+		}
+	}
+}
+
+func TestLibpqDSN(t *testing.T) {
+	tests := []struct {
+		endpoint databaseEndpoint
+		want     string
+	}{
+		{
+			endpoint: databaseEndpoint{Host: "pg", Port: 5432, Database: "app", User: "u", Password: "secret"},
+			want:     "host=pg port=5432 dbname=app user=u password=secret",
+		},
+		{
+			endpoint: databaseEndpoint{Host: "pg", Port: 5432, Password: `it's a\b`},
+			want:     `host=pg port=5432 password='it\'s a\\b'`,
+		},
+	}
+	for _, test := range tests {
+		if got := libpqDSN(test.endpoint); got != test.want {
+			t.Errorf("got %q, want %q", got, test.want)
+		}
+	}
+}
+
+func TestMySQLDSN(t *testing.T) {
+	tests := []struct {
+		endpoint databaseEndpoint
+		want     string
+	}{
+		{
+			endpoint: databaseEndpoint{Host: "my", Port: 3306, Database: "app", User: "u", Password: "p@ss"},
+			want:     "mysql://u:p%40ss@my:3306/app",
+		},
+		{
+			endpoint: databaseEndpoint{Host: "my", Port: 3306, User: "u"},
+			want:     "mysql://u@my:3306",
+		},
+	}
+	for _, test := range tests {
+		if got := mysqlDSN(test.endpoint); got != test.want {
+			t.Errorf("got %q, want %q", got, test.want)
+		}
+	}
+}
+
+func TestEZConnectDSN(t *testing.T) {
+	got, err := ezConnectDSN(databaseEndpoint{Host: "ora", Port: 1521, ServiceName: "ORCL"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "//ora:1521/ORCL"; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+	if _, err := ezConnectDSN(databaseEndpoint{Host: "ora", Port: 1521, SID: "XE"}); err == nil {
+		t.Error("expected an error without a service name")
+	}
+}
+
+func TestDatabaseEndpointFromAccount(t *testing.T) {
+	endpoint, err := databaseEndpointFromAccount(map[string]string{
+		"address":         " db.example.com ",
+		"account_name":    "sa",
+		"password":        "secret",
+		"sql_server_port": "14330",
+	}, "sqlserver")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if endpoint.Host != "db.example.com" || endpoint.Port != 14330 || endpoint.User != "sa" || endpoint.Password != "secret" {
+		t.Errorf("unexpected endpoint %+v", endpoint)
+	}
+
+	endpoint, err = databaseEndpointFromAccount(map[string]string{"address": "pg"}, "postgresql")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if endpoint.Port != 5432 {
+		t.Errorf("default port = %d, want 5432", endpoint.Port)
+	}
+
+	if _, err := databaseEndpointFromAccount(map[string]string{}, "mysql"); err == nil {
+		t.Error("expected an error without an address")
+	}
+	if _, err := databaseEndpointFromAccount(map[string]string{"address": "my", "mysql_port": "70000"}, "mysql"); err == nil {
+		t.Error("expected an error for an out of range port")
+	}
+}
+
+func TestDetectDatabaseEngine(t *testing.T) {
+	tests := []struct {
+		account map[string]string
+		want    string
+	}{
+		{map[string]string{"sql_server_port": "1433"}, "sqlserver"},
+		{map[string]string{"mysql_port": "3306"}, "mysql"},
+		{map[string]string{"oracle_service_name": "ORCL"}, "oracle"},
+		{map[string]string{"account_type": "Microsoft SQL Server"}, "sqlserver"},
+		{map[string]string{"account_type": "MariaDB"}, "mysql"},
+		{map[string]string{"account_type": "PostgreSQL"}, "postgresql"},
+		{map[string]string{"account_type": "Linux Account"}, ""},
+	}
+	for _, test := range tests {
+		if got := detectDatabaseEngine(test.account); got != test.want {
+			t.Errorf("detectDatabaseEngine(%v) = %q, want %q", test.account, got, test.want)
+		}
+	}
+}
@@ -5,8 +5,10 @@ import (
 	"fmt"
 	"net/http"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
@@ -41,6 +43,9 @@ func (d *DeleteAccounts) Schema(ctx context.Context, req datasource.SchemaReques
 				ElementType:         types.Int64Type,
 				MarkdownDescription: "List of account IDs to be deleted.",
 				Required:            true,
+				Validators: []validator.List{
+					listvalidator.SizeAtLeast(1),
+				},
 			},
 			"reason": schema.StringAttribute{
 				MarkdownDescription: "Reason for deleting the accounts.",
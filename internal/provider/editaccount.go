@@ -5,12 +5,19 @@ import (
 	"fmt"
 	"net/http"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/datasourcevalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
 var _ datasource.DataSource = &EditAccount{}
+var _ datasource.DataSourceWithValidateConfig = &EditAccount{}
+var _ datasource.DataSourceWithConfigValidators = &EditAccount{}
 
 func edit_account() datasource.DataSource {
 	return &EditAccount{}
@@ -27,9 +34,13 @@ type EditAccountModel struct {
 	AccountType               types.String `tfsdk:"account_type"`
 	IPAddress                 types.String `tfsdk:"ipaddress"`
 	Notes                     types.String `tfsdk:"notes"`
-	Tags                      types.String `tfsdk:"tags"`
+	Tags                      types.Set    `tfsdk:"tags"`
+	TagsMode                  types.String `tfsdk:"tags_mode"`
 	FolderID                  types.Int64  `tfsdk:"folder_id"`
+	FolderPath                types.String `tfsdk:"folder_path"`
 	OverwriteAdditionalFields types.Bool   `tfsdk:"overwrite_additional_fields"`
+	AdditionalFields          types.Map    `tfsdk:"additional_fields"`
+	SensitiveAdditionalFields types.Map    `tfsdk:"sensitive_additional_fields"`
 	AccountExpirationDate     types.String `tfsdk:"account_expiration_date"`
 	DistinguishedName         types.String `tfsdk:"distinguished_name"`
 	AccountAlias              types.String `tfsdk:"account_alias"`
@@ -53,10 +64,16 @@ func (d *EditAccount) Schema(ctx context.Context, req datasource.SchemaRequest,
 			"account_type": schema.StringAttribute{
 				MarkdownDescription: "Specifies the type of the account.",
 				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
 			},
 			"account_title": schema.StringAttribute{
 				MarkdownDescription: "The title associated with the account.",
 				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
 			},
 			"account_name": schema.StringAttribute{
 				MarkdownDescription: "The name associated with the account.",
@@ -65,27 +82,55 @@ func (d *EditAccount) Schema(ctx context.Context, req datasource.SchemaRequest,
 			"ipaddress": schema.StringAttribute{
 				MarkdownDescription: "The IP address of the account (if applicable).",
 				Optional:            true,
+				Validators: []validator.String{
+					hostAddress(),
+				},
 			},
 			"notes": schema.StringAttribute{
 				MarkdownDescription: "Additional notes related to the account.",
 				Optional:            true,
 			},
-			"tags": schema.StringAttribute{
-				MarkdownDescription: "Tags associated with the account.",
+			"tags": schema.SetAttribute{
+				ElementType:         types.StringType,
+				MarkdownDescription: "Tags associated with the account. In `additive` mode the tags are added to the existing tags of the account.",
 				Optional:            true,
+				Validators:          tagsValidators(),
+			},
+			"tags_mode": schema.StringAttribute{
+				MarkdownDescription: "How `tags` is applied to the account. `authoritative` (default) replaces every tag on the account, `additive` only adds the configured tags and keeps any other tag.",
+				Optional:            true,
+				Validators:          tagsModeValidators(),
 			},
 			"folder_id": schema.Int64Attribute{
 				MarkdownDescription: "The ID of the folder where the account belongs to.",
 				Optional:            true,
 			},
+			"folder_path": schema.StringAttribute{
+				MarkdownDescription: "Path of the folder where the account is stored, for example `Prod/Databases/Oracle`. Resolved to a folder ID when the account is saved. Conflicts with `folder_id`.",
+				Optional:            true,
+			},
 			"account_expiration_date": schema.StringAttribute{
-				MarkdownDescription: "The expiration date of the account (format: DD/MM/YYYY).",
+				MarkdownDescription: "The expiration date of the account, as a `DD/MM/YYYY` date, an RFC 3339 timestamp or a duration relative to apply time such as `+90d` or `+12w`. Sent to Securden as `DD/MM/YYYY`.",
 				Optional:            true,
+				Validators: []validator.String{
+					expirationDate(),
+				},
 			},
 			"distinguished_name": schema.StringAttribute{
 				MarkdownDescription: "Required for LDAP domain accounts.",
 				Optional:            true,
 			},
+			"additional_fields": schema.MapAttribute{
+				ElementType:         types.StringType,
+				MarkdownDescription: "Additional (custom) fields of the account, such as an owner or a cost center, keyed by field name.",
+				Optional:            true,
+			},
+			"sensitive_additional_fields": schema.MapAttribute{
+				ElementType:         types.StringType,
+				MarkdownDescription: "Additional (custom) fields of the account holding secret values, keyed by field name. A field name cannot be set in both `additional_fields` and `sensitive_additional_fields`.",
+				Optional:            true,
+				Sensitive:           true,
+			},
 			"overwrite_additional_fields": schema.BoolAttribute{
 				MarkdownDescription: "Indicates whether additional fields should be overwritten (true/false).",
 				Optional:            true,
@@ -121,6 +166,21 @@ func (d *EditAccount) Configure(ctx context.Context, req datasource.ConfigureReq
 	d.client = client
 }
 
+func (d *EditAccount) ConfigValidators(ctx context.Context) []datasource.ConfigValidator {
+	return []datasource.ConfigValidator{
+		datasourcevalidator.Conflicting(path.MatchRoot("folder_id"), path.MatchRoot("folder_path")),
+	}
+}
+
+func (d *EditAccount) ValidateConfig(ctx context.Context, req datasource.ValidateConfigRequest, resp *datasource.ValidateConfigResponse) {
+	var config EditAccountModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	validateAdditionalFields(ctx, config.AdditionalFields, config.SensitiveAdditionalFields, &resp.Diagnostics)
+}
+
 func (d *EditAccount) Create(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
 	var account EditAccountModel
 	resp.Diagnostics.Append(req.Config.Get(ctx, &account)...)
@@ -131,18 +191,26 @@ func (d *EditAccount) Create(ctx context.Context, req datasource.ReadRequest, re
 	setParam(params, "account_type", account.AccountType)
 	setParam(params, "ipaddress", account.IPAddress)
 	setParam(params, "notes", account.Notes)
-	setParam(params, "tags", account.Tags)
+	setEditTagsParam(ctx, params, account, &resp.Diagnostics)
 	setParam(params, "folder_id", account.FolderID)
+	setFolderParam(ctx, params, account.FolderPath, &resp.Diagnostics)
 	setParam(params, "overwrite_additional_fields", account.OverwriteAdditionalFields)
-	setParam(params, "account_expiration_date", account.AccountExpirationDate)
+	setExpirationParam(params, account.AccountExpirationDate, &resp.Diagnostics)
 	setParam(params, "distinguished_name", account.DistinguishedName)
 	setParam(params, "account_alias", account.AccountAlias)
 	setParam(params, "domain_name", account.DomainName)
+	setAdditionalFieldsParam(ctx, params, account.AdditionalFields, account.SensitiveAdditionalFields, nil, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 	edit_account, code, message := edit_account_function(ctx, params)
 	if code != 200 && code != 0 {
 		resp.Diagnostics.AddWarning(fmt.Sprintf("%d - %s", code, message), "")
 		return
 	}
+	edit_account.Tags = account.Tags
+	edit_account.AdditionalFields = account.AdditionalFields
+	edit_account.SensitiveAdditionalFields = account.SensitiveAdditionalFields
 	resp.Diagnostics.Append(resp.State.Set(ctx, &edit_account)...)
 }
 
@@ -156,17 +224,42 @@ func (d *EditAccount) Read(ctx context.Context, req datasource.ReadRequest, resp
 	setParam(params, "account_type", account.AccountType)
 	setParam(params, "ipaddress", account.IPAddress)
 	setParam(params, "notes", account.Notes)
-	setParam(params, "tags", account.Tags)
+	setEditTagsParam(ctx, params, account, &resp.Diagnostics)
 	setParam(params, "folder_id", account.FolderID)
+	setFolderParam(ctx, params, account.FolderPath, &resp.Diagnostics)
 	setParam(params, "overwrite_additional_fields", account.OverwriteAdditionalFields)
-	setParam(params, "account_expiration_date", account.AccountExpirationDate)
+	setExpirationParam(params, account.AccountExpirationDate, &resp.Diagnostics)
 	setParam(params, "distinguished_name", account.DistinguishedName)
 	setParam(params, "account_alias", account.AccountAlias)
 	setParam(params, "domain_name", account.DomainName)
+	setAdditionalFieldsParam(ctx, params, account.AdditionalFields, account.SensitiveAdditionalFields, nil, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 	edit_account, code, message := edit_account_function(ctx, params)
 	if code != 200 && code != 0 {
 		resp.Diagnostics.AddWarning(fmt.Sprintf("%d - %s", code, message), "")
 		return
 	}
+	edit_account.Tags = account.Tags
+	edit_account.AdditionalFields = account.AdditionalFields
+	edit_account.SensitiveAdditionalFields = account.SensitiveAdditionalFields
 	resp.Diagnostics.Append(resp.State.Set(ctx, &edit_account)...)
 }
+
+// setEditTagsParam sends the configured tags, merged with the tags already on
+// the account in additive mode.
+func setEditTagsParam(ctx context.Context, params map[string]any, account EditAccountModel, diags *diag.Diagnostics) {
+	tags := tagsFromSet(ctx, account.Tags, diags)
+	if tags == nil {
+		return
+	}
+	if isAdditiveTags(account.TagsMode) {
+		remote := accountTags(ctx, account.AccountID.ValueInt64(), diags)
+		if diags.HasError() {
+			return
+		}
+		tags = mergeTags(remote, nil, tags)
+	}
+	setTagsParam(params, tags)
+}
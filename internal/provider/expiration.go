@@ -0,0 +1,195 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// defaultExpirationWarningDays is used when the provider block does not set
+// expiration_warning_days, warnings are opt-in.
+var defaultExpirationWarningDays int64 = 0
+
+// expirationDateLayout is the DD/MM/YYYY format Securden expects for
+// account_expiration_date.
+var expirationDateLayout = "02/01/2006"
+
+var relativeExpirationPattern = regexp.MustCompile(`^\+(\d+)([dw])$`)
+
+// parseExpirationDate accepts a DD/MM/YYYY date, an RFC 3339 timestamp or
+// date, or a duration relative to now such as +90d, +12w or +36h.
+func parseExpirationDate(value string, now time.Time) (time.Time, error) {
+	value = strings.TrimSpace(value)
+	if strings.HasPrefix(value, "+") {
+		if match := relativeExpirationPattern.FindStringSubmatch(value); match != nil {
+			count, err := strconv.Atoi(match[1])
+			if err != nil {
+				return time.Time{}, err
+			}
+			if match[2] == "w" {
+				count *= 7
+			}
+			return now.AddDate(0, 0, count), nil
+		}
+		duration, err := time.ParseDuration(value[1:])
+		if err != nil {
+			return time.Time{}, fmt.Errorf("invalid relative expiration %q", value)
+		}
+		return now.Add(duration), nil
+	}
+	for _, layout := range []string{expirationDateLayout, time.RFC3339, time.DateOnly} {
+		if date, err := time.Parse(layout, value); err == nil {
+			return date, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid expiration date %q", value)
+}
+
+// normalizeExpirationDate converts any accepted expiration format to the
+// DD/MM/YYYY date sent to Securden.
+func normalizeExpirationDate(value string, now time.Time) (string, error) {
+	date, err := parseExpirationDate(value, now)
+	if err != nil {
+		return "", err
+	}
+	return date.Format(expirationDateLayout), nil
+}
+
+// setExpirationParam normalizes the configured account_expiration_date and
+// stores it as a request parameter.
+func setExpirationParam(params map[string]any, value types.String, diags *diag.Diagnostics) {
+	if value.IsNull() || value.IsUnknown() || value.ValueString() == "" {
+		return
+	}
+	date, err := normalizeExpirationDate(value.ValueString(), time.Now().UTC())
+	if err != nil {
+		diags.AddAttributeError(path.Root("account_expiration_date"), "Invalid Date", err.Error())
+		return
+	}
+	params["account_expiration_date"] = date
+}
+
+// expirationAttributes derives expires_at and days_until_expiration from the
+// expiration date Securden reports. Both are null when the account does not
+// expire.
+func expirationAttributes(value string, now time.Time) (types.String, types.Int64) {
+	if strings.TrimSpace(value) == "" || strings.HasPrefix(strings.TrimSpace(value), "+") {
+		return types.StringNull(), types.Int64Null()
+	}
+	date, err := parseExpirationDate(value, now)
+	if err != nil {
+		return types.StringNull(), types.Int64Null()
+	}
+	expiry := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, time.UTC)
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	days := int64(expiry.Sub(today).Hours() / 24)
+	return types.StringValue(date.Format(time.RFC3339)), types.Int64Value(days)
+}
+
+var _ planmodifier.String = expirationPlanModifier{}
+var _ planmodifier.Int64 = expirationPlanModifier{}
+
+// expirationPlanModifier plans expires_at and days_until_expiration from
+// account_expiration_date: the state value is kept while the date is
+// unchanged, the attributes become null when the date is removed and stay
+// unknown until apply when it changes.
+type expirationPlanModifier struct{}
+
+func expirationFromDate() expirationPlanModifier {
+	return expirationPlanModifier{}
+}
+
+func (m expirationPlanModifier) Description(ctx context.Context) string {
+	return "follows changes to account_expiration_date"
+}
+
+func (m expirationPlanModifier) MarkdownDescription(ctx context.Context) string {
+	return "follows changes to `account_expiration_date`"
+}
+
+func (m expirationPlanModifier) PlanModifyString(ctx context.Context, req planmodifier.StringRequest, resp *planmodifier.StringResponse) {
+	if !req.PlanValue.IsUnknown() {
+		return
+	}
+	switch m.planDate(ctx, req.Plan, req.State, &resp.Diagnostics) {
+	case expirationDateUnchanged:
+		resp.PlanValue = req.StateValue
+	case expirationDateRemoved:
+		resp.PlanValue = types.StringNull()
+	}
+}
+
+func (m expirationPlanModifier) PlanModifyInt64(ctx context.Context, req planmodifier.Int64Request, resp *planmodifier.Int64Response) {
+	if !req.PlanValue.IsUnknown() {
+		return
+	}
+	switch m.planDate(ctx, req.Plan, req.State, &resp.Diagnostics) {
+	case expirationDateUnchanged:
+		resp.PlanValue = req.StateValue
+	case expirationDateRemoved:
+		resp.PlanValue = types.Int64Null()
+	}
+}
+
+// Planned changes of account_expiration_date.
+const (
+	expirationDateUnknown = iota
+	expirationDateUnchanged
+	expirationDateRemoved
+)
+
+func (m expirationPlanModifier) planDate(ctx context.Context, plan tfsdk.Plan, state tfsdk.State, diags *diag.Diagnostics) int {
+	if state.Raw.IsNull() || plan.Raw.IsNull() {
+		return expirationDateUnknown
+	}
+	var planned, current types.String
+	diags.Append(plan.GetAttribute(ctx, path.Root("account_expiration_date"), &planned)...)
+	diags.Append(state.GetAttribute(ctx, path.Root("account_expiration_date"), &current)...)
+	switch {
+	case diags.HasError() || planned.IsUnknown():
+		return expirationDateUnknown
+	case planned.Equal(current):
+		return expirationDateUnchanged
+	case planned.IsNull():
+		return expirationDateRemoved
+	}
+	return expirationDateUnknown
+}
+
+// addExpirationWarning warns when an account has expired or expires within
+// window days. A window of zero disables the warning.
+func addExpirationWarning(diags *diag.Diagnostics, label string, expiresAt types.String, days types.Int64, window int64) {
+	if window <= 0 || days.IsNull() || days.IsUnknown() || days.ValueInt64() > window {
+		return
+	}
+	date := expiresAt.ValueString()
+	if parsed, err := time.Parse(time.RFC3339, date); err == nil {
+		date = parsed.Format(time.DateOnly)
+	}
+	remaining := days.ValueInt64()
+	switch {
+	case remaining < 0:
+		diags.AddWarning("Account Expired", fmt.Sprintf("Account %s expired on %s, %d days ago.", label, date, -remaining))
+	case remaining == 0:
+		diags.AddWarning("Account Expiring Today", fmt.Sprintf("Account %s expires today (%s).", label, date))
+	default:
+		diags.AddWarning("Account Expiring Soon", fmt.Sprintf("Account %s expires on %s, in %d days.", label, date, remaining))
+	}
+}
+
+// accountLabel names an account in diagnostics by title and ID.
+func accountLabel(title string, id int64) string {
+	if title == "" {
+		return strconv.FormatInt(id, 10)
+	}
+	return fmt.Sprintf("%q (%d)", title, id)
+}
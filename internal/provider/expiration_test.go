@@ -0,0 +1,109 @@
+package provider
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var testNow = time.Date(2024, time.March, 10, 15, 30, 0, 0, time.UTC)
+
+func TestParseExpirationDate(t *testing.T) {
+	tests := []struct {
+		value string
+		want  time.Time
+	}{
+		{"31/12/2024", time.Date(2024, time.December, 31, 0, 0, 0, 0, time.UTC)},
+		{" 01/02/2025 ", time.Date(2025, time.February, 1, 0, 0, 0, 0, time.UTC)},
+		{"2024-12-31", time.Date(2024, time.December, 31, 0, 0, 0, 0, time.UTC)},
+		{"2024-12-31T23:00:00Z", time.Date(2024, time.December, 31, 23, 0, 0, 0, time.UTC)},
+		{"+90d", testNow.AddDate(0, 0, 90)},
+		{"+2w", testNow.AddDate(0, 0, 14)},
+		{"+36h", testNow.Add(36 * time.Hour)},
+	}
+	for _, test := range tests {
+		got, err := parseExpirationDate(test.value, testNow)
+		if err != nil {
+			t.Errorf("parseExpirationDate(%q): %v", test.value, err)
+			continue
+		}
+		if !got.Equal(test.want) {
+			t.Errorf("parseExpirationDate(%q) = %s, want %s", test.value, got, test.want)
+		}
+	}
+}
+
+func TestParseExpirationDateInvalid(t *testing.T) {
+	for _, value := range []string{"", "31/02/2024", "12/31/2024", "+90x", "+", "tomorrow"} {
+		if _, err := parseExpirationDate(value, testNow); err == nil {
+			t.Errorf("parseExpirationDate(%q): expected an error", value)
+		}
+	}
+}
+
+func TestNormalizeExpirationDate(t *testing.T) {
+	tests := map[string]string{
+		"2024-12-31T23:00:00Z": "31/12/2024",
+		"+90d":                 "08/06/2024",
+		"05/04/2024":           "05/04/2024",
+	}
+	for value, want := range tests {
+		got, err := normalizeExpirationDate(value, testNow)
+		if err != nil {
+			t.Errorf("normalizeExpirationDate(%q): %v", value, err)
+			continue
+		}
+		if got != want {
+			t.Errorf("normalizeExpirationDate(%q) = %q, want %q", value, got, want)
+		}
+	}
+}
+
+func TestExpirationAttributes(t *testing.T) {
+	tests := []struct {
+		value    string
+		wantAt   types.String
+		wantDays types.Int64
+	}{
+		{"20/03/2024", types.StringValue("2024-03-20T00:00:00Z"), types.Int64Value(10)},
+		{"10/03/2024", types.StringValue("2024-03-10T00:00:00Z"), types.Int64Value(0)},
+		{"01/03/2024", types.StringValue("2024-03-01T00:00:00Z"), types.Int64Value(-9)},
+		{"", types.StringNull(), types.Int64Null()},
+		{"+90d", types.StringNull(), types.Int64Null()},
+		{"never", types.StringNull(), types.Int64Null()},
+	}
+	for _, test := range tests {
+		at, days := expirationAttributes(test.value, testNow)
+		if !at.Equal(test.wantAt) || !days.Equal(test.wantDays) {
+			t.Errorf("expirationAttributes(%q) = %s, %s, want %s, %s", test.value, at, days, test.wantAt, test.wantDays)
+		}
+	}
+}
+
+func TestAddExpirationWarning(t *testing.T) {
+	tests := []struct {
+		days    types.Int64
+		window  int64
+		summary string
+	}{
+		{types.Int64Value(-3), 30, "Account Expired"},
+		{types.Int64Value(0), 30, "Account Expiring Today"},
+		{types.Int64Value(12), 30, "Account Expiring Soon"},
+		{types.Int64Value(31), 30, ""},
+		{types.Int64Value(-3), 0, ""},
+		{types.Int64Null(), 30, ""},
+	}
+	for _, test := range tests {
+		var diags diag.Diagnostics
+		addExpirationWarning(&diags, accountLabel("Orders DB", 42), types.StringValue("2024-03-20T00:00:00Z"), test.days, test.window)
+		summary := ""
+		if len(diags) > 0 {
+			summary = diags[0].Summary()
+		}
+		if summary != test.summary {
+			t.Errorf("days=%s window=%d: got %q, want %q", test.days, test.window, summary, test.summary)
+		}
+	}
+}
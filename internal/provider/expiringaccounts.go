@@ -0,0 +1,216 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ datasource.DataSource = &ExpiringAccounts{}
+
+func expiring_accounts() datasource.DataSource {
+	return &ExpiringAccounts{}
+}
+
+type ExpiringAccounts struct {
+	client *http.Client
+}
+
+type ExpiringAccountsModel struct {
+	Before         types.String           `tfsdk:"before"`
+	AccountType    types.String           `tfsdk:"account_type"`
+	FolderID       types.Int64            `tfsdk:"folder_id"`
+	IncludeExpired types.Bool             `tfsdk:"include_expired"`
+	MaxResults     types.Int64            `tfsdk:"max_results"`
+	IDs            []types.Int64          `tfsdk:"ids"`
+	Accounts       []ExpiringAccountModel `tfsdk:"accounts"`
+}
+
+type ExpiringAccountModel struct {
+	AccountID           types.Int64  `tfsdk:"account_id"`
+	AccountName         types.String `tfsdk:"account_name"`
+	AccountTitle        types.String `tfsdk:"account_title"`
+	AccountType         types.String `tfsdk:"account_type"`
+	FolderID            types.Int64  `tfsdk:"folder_id"`
+	ExpiresAt           types.String `tfsdk:"expires_at"`
+	DaysUntilExpiration types.Int64  `tfsdk:"days_until_expiration"`
+}
+
+// expiringAccountEntry is an account as returned by get_expiring_accounts.
+type expiringAccountEntry struct {
+	AccountID      int64
+	AccountName    string
+	AccountTitle   string
+	AccountType    string
+	FolderID       int64
+	ExpirationDate string
+}
+
+func (d *ExpiringAccounts) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_expiring_accounts"
+}
+
+func (d *ExpiringAccounts) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Lists the accounts that expire on or before a given date.",
+
+		Attributes: map[string]schema.Attribute{
+			"before": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Only return accounts expiring on or before this date, as a `DD/MM/YYYY` date, an RFC 3339 timestamp or a duration from now such as `+30d`.",
+				Validators: []validator.String{
+					expirationDate(),
+				},
+			},
+			"account_type": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Only return accounts of this type.",
+			},
+			"folder_id": schema.Int64Attribute{
+				Optional:            true,
+				MarkdownDescription: "Only return accounts stored in this folder.",
+			},
+			"include_expired": schema.BoolAttribute{
+				Optional:            true,
+				MarkdownDescription: "Include accounts that have already expired. Defaults to true.",
+			},
+			"max_results": maxResultsAttribute("Maximum number of matching accounts to return, the soonest expiring ones. Defaults to no limit."),
+			"ids": schema.ListAttribute{
+				ElementType:         types.Int64Type,
+				Computed:            true,
+				MarkdownDescription: "IDs of the matching accounts, soonest expiration first.",
+			},
+			"accounts": schema.ListNestedAttribute{
+				Computed:            true,
+				MarkdownDescription: "The matching accounts, soonest expiration first.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"account_id": schema.Int64Attribute{
+							Computed:            true,
+							MarkdownDescription: "Unique identifier of the account.",
+						},
+						"account_name": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "The name associated with the account.",
+						},
+						"account_title": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Title of the account.",
+						},
+						"account_type": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Type of the account.",
+						},
+						"folder_id": schema.Int64Attribute{
+							Computed:            true,
+							MarkdownDescription: "ID of the folder the account is stored in.",
+						},
+						"expires_at": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "The expiration date of the account as an RFC 3339 timestamp.",
+						},
+						"days_until_expiration": schema.Int64Attribute{
+							Computed:            true,
+							MarkdownDescription: "Number of days until the account expires, negative once it has expired.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *ExpiringAccounts) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	client, ok := req.ProviderData.(*http.Client)
+	if !ok {
+		resp.Diagnostics.AddWarning(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *http.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+	d.client = client
+}
+
+func (d *ExpiringAccounts) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data ExpiringAccountsModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	now := time.Now().UTC()
+	before, err := parseExpirationDate(data.Before.ValueString(), now)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("before"), "Invalid Date", err.Error())
+		return
+	}
+	params := map[string]any{"before": before.Format(expirationDateLayout)}
+	setParam(params, "account_type", data.AccountType)
+	setParam(params, "folder_id", data.FolderID)
+	includeExpired := data.IncludeExpired.IsNull() || data.IncludeExpired.ValueBool()
+	_, daysBefore := expirationAttributes(before.Format(expirationDateLayout), now)
+	// The server filters as well, the checks are repeated so older servers
+	// that ignore the parameters return the same result.
+	keep := func(entry expiringAccountEntry) bool {
+		_, days := expirationAttributes(entry.ExpirationDate, now)
+		// before is inclusive, accounts expiring on that day are kept.
+		if days.IsNull() || days.ValueInt64() > daysBefore.ValueInt64() || (!includeExpired && days.ValueInt64() < 0) {
+			return false
+		}
+		if !data.AccountType.IsNull() && !strings.EqualFold(entry.AccountType, data.AccountType.ValueString()) {
+			return false
+		}
+		return data.FolderID.IsNull() || entry.FolderID == data.FolderID.ValueInt64()
+	}
+	// The accounts are sorted by expiration, so every match is read and the
+	// cap is applied to the sorted accounts.
+	entries, code, message := get_expiring_accounts(ctx, params, keep)
+	if code != 200 {
+		resp.Diagnostics.AddError(fmt.Sprintf("%d - %s", code, message), "")
+		return
+	}
+	data.IDs = []types.Int64{}
+	data.Accounts = []ExpiringAccountModel{}
+	for _, entry := range entries {
+		expiresAt, days := expirationAttributes(entry.ExpirationDate, now)
+		account := ExpiringAccountModel{
+			AccountID:           types.Int64Value(entry.AccountID),
+			AccountName:         types.StringValue(entry.AccountName),
+			AccountTitle:        types.StringValue(entry.AccountTitle),
+			AccountType:         types.StringValue(entry.AccountType),
+			FolderID:            types.Int64Null(),
+			ExpiresAt:           expiresAt,
+			DaysUntilExpiration: days,
+		}
+		if entry.FolderID != 0 {
+			account.FolderID = types.Int64Value(entry.FolderID)
+		}
+		data.Accounts = append(data.Accounts, account)
+	}
+	sort.SliceStable(data.Accounts, func(i, j int) bool {
+		left, right := data.Accounts[i], data.Accounts[j]
+		if left.DaysUntilExpiration.ValueInt64() != right.DaysUntilExpiration.ValueInt64() {
+			return left.DaysUntilExpiration.ValueInt64() < right.DaysUntilExpiration.ValueInt64()
+		}
+		return left.AccountID.ValueInt64() < right.AccountID.ValueInt64()
+	})
+	if maxResults := int(data.MaxResults.ValueInt64()); maxResults > 0 && len(data.Accounts) > maxResults {
+		data.Accounts = data.Accounts[:maxResults]
+	}
+	for _, account := range data.Accounts {
+		data.IDs = append(data.IDs, account.AccountID)
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
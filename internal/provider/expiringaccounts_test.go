@@ -0,0 +1,78 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestExpiringAccountsRead(t *testing.T) {
+	now := time.Now().UTC()
+	date := func(days int) string {
+		return now.AddDate(0, 0, days).Format(expirationDateLayout)
+	}
+	fake := newFakeServer(t, map[string]fakeHandler{
+		"/secretsmanagement/get_expiring_accounts": func(params map[string]any) any {
+			// The server ignores the filters, like older releases do.
+			return map[string]any{"status_code": 200, "accounts": []map[string]any{
+				{"account_id": 5, "account_title": "In five days", "account_type": "MySQL", "account_expiration_date": date(5)},
+				{"account_id": 31, "account_title": "After before", "account_type": "MySQL", "account_expiration_date": date(31)},
+				{"account_id": 30, "account_title": "On before", "account_type": "MySQL", "account_expiration_date": date(30), "folder_id": 12},
+				{"account_id": 3, "account_title": "Expired", "account_type": "MySQL", "account_expiration_date": date(-3)},
+				{"account_id": 1, "account_title": "Tomorrow", "account_type": "Linux", "account_expiration_date": date(1)},
+				{"account_id": 7, "account_title": "No expiration", "account_type": "MySQL"},
+			}}
+		},
+	})
+
+	tests := []struct {
+		name   string
+		config ExpiringAccountsModel
+		want   []int64
+	}{
+		{
+			name:   "inclusive before, soonest first",
+			config: ExpiringAccountsModel{Before: types.StringValue("+30d")},
+			want:   []int64{3, 1, 5, 30},
+		},
+		{
+			name:   "without expired accounts",
+			config: ExpiringAccountsModel{Before: types.StringValue("+30d"), IncludeExpired: types.BoolValue(false)},
+			want:   []int64{1, 5, 30},
+		},
+		{
+			name:   "by type",
+			config: ExpiringAccountsModel{Before: types.StringValue(date(10)), AccountType: types.StringValue("mysql")},
+			want:   []int64{3, 5},
+		},
+		{
+			name:   "soonest first before max_results",
+			config: ExpiringAccountsModel{Before: types.StringValue("+30d"), MaxResults: types.Int64Value(2)},
+			want:   []int64{3, 1},
+		},
+		{
+			name:   "by folder",
+			config: ExpiringAccountsModel{Before: types.StringValue("+60d"), FolderID: types.Int64Value(12)},
+			want:   []int64{30},
+		},
+	}
+	for _, test := range tests {
+		var got ExpiringAccountsModel
+		if diags := readDataSource(t, &ExpiringAccounts{}, &test.config, &got); diags.HasError() {
+			t.Fatalf("%s: %v", test.name, diags)
+		}
+		if fmt.Sprint(got.IDs) != fmt.Sprint(test.want) {
+			t.Errorf("%s: ids = %v, want %v", test.name, got.IDs, test.want)
+		}
+		for i, account := range got.Accounts {
+			if !account.AccountID.Equal(got.IDs[i]) || account.ExpiresAt.IsNull() {
+				t.Errorf("%s: account %d = %+v", test.name, i, account)
+			}
+		}
+	}
+	if calls := fake.calls("/secretsmanagement/get_expiring_accounts"); calls[0]["before"] != date(30) {
+		t.Errorf("before sent as %v, want %s", calls[0]["before"], date(30))
+	}
+}
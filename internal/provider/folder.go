@@ -0,0 +1,128 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/datasourcevalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ datasource.DataSource = &Folder{}
+var _ datasource.DataSourceWithConfigValidators = &Folder{}
+
+func folder() datasource.DataSource {
+	return &Folder{}
+}
+
+type Folder struct {
+	client *http.Client
+}
+
+type FolderModel struct {
+	FolderID    types.Int64  `tfsdk:"folder_id"`
+	Path        types.String `tfsdk:"path"`
+	Name        types.String `tfsdk:"name"`
+	ParentID    types.Int64  `tfsdk:"parent_id"`
+	Description types.String `tfsdk:"description"`
+}
+
+func (d *Folder) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_folder"
+}
+
+func (d *Folder) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Retrieves a folder from Securden by ID or by path.",
+
+		Attributes: map[string]schema.Attribute{
+			"folder_id": schema.Int64Attribute{
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "Unique identifier of the folder. Exactly one of `folder_id` and `path` must be set.",
+			},
+			"path": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "Full path of the folder, for example `Prod/Databases/Oracle`. Exactly one of `folder_id` and `path` must be set.",
+			},
+			"name": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Name of the folder.",
+			},
+			"parent_id": schema.Int64Attribute{
+				Computed:            true,
+				MarkdownDescription: "ID of the parent folder, null for top level folders.",
+			},
+			"description": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Description of the folder.",
+			},
+		},
+	}
+}
+
+func (d *Folder) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	client, ok := req.ProviderData.(*http.Client)
+	if !ok {
+		resp.Diagnostics.AddWarning(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *http.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+	d.client = client
+}
+
+func (d *Folder) ConfigValidators(ctx context.Context) []datasource.ConfigValidator {
+	return []datasource.ConfigValidator{
+		datasourcevalidator.ExactlyOneOf(path.MatchRoot("folder_id"), path.MatchRoot("path")),
+	}
+}
+
+func (d *Folder) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data FolderModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	entries, code, message := get_folders(ctx)
+	if code != 200 {
+		resp.Diagnostics.AddError(fmt.Sprintf("%d - %s", code, message), "")
+		return
+	}
+	var entry folderEntry
+	found := false
+	if !data.FolderID.IsNull() {
+		for _, candidate := range entries {
+			if candidate.ID == data.FolderID.ValueInt64() {
+				entry, found = candidate, true
+				break
+			}
+		}
+		if !found {
+			resp.Diagnostics.AddError("404 - Folder not found", fmt.Sprintf("No folder with ID %d exists.", data.FolderID.ValueInt64()))
+			return
+		}
+	} else {
+		entry, found = resolveFolderPath(entries, data.Path.ValueString())
+		if !found {
+			resp.Diagnostics.AddError("404 - Folder not found", fmt.Sprintf("No folder exists at path %q.", data.Path.ValueString()))
+			return
+		}
+	}
+	summary := entry.summary(folderPaths(entries)[entry.ID])
+	data.FolderID = summary.FolderID
+	data.Path = summary.Path
+	data.Name = summary.Name
+	data.ParentID = summary.ParentID
+	data.Description = summary.Description
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
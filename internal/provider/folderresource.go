@@ -0,0 +1,203 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ resource.Resource = &FolderResource{}
+var _ resource.ResourceWithConfigure = &FolderResource{}
+var _ resource.ResourceWithImportState = &FolderResource{}
+
+func folder_resource() resource.Resource {
+	return &FolderResource{}
+}
+
+type FolderResource struct {
+	client *http.Client
+}
+
+type FolderResourceModel struct {
+	ID          types.Int64  `tfsdk:"id"`
+	Name        types.String `tfsdk:"name"`
+	ParentID    types.Int64  `tfsdk:"parent_id"`
+	Description types.String `tfsdk:"description"`
+	Path        types.String `tfsdk:"path"`
+}
+
+func (r *FolderResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_folder"
+}
+
+func (r *FolderResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages a folder in Securden.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.Int64Attribute{
+				Computed:            true,
+				MarkdownDescription: "Unique identifier of the folder in Securden.",
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+			"name": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Name of the folder.",
+			},
+			"parent_id": schema.Int64Attribute{
+				Optional:            true,
+				MarkdownDescription: "ID of the parent folder. Leave unset to create a top level folder.",
+			},
+			"description": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Description of the folder.",
+			},
+			"path": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Full path of the folder, for example `Prod/Databases/Oracle`.",
+			},
+		},
+	}
+}
+
+func (r *FolderResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	client, ok := req.ProviderData.(*http.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *http.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+	r.client = client
+}
+
+func (r *FolderResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan FolderResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	id, code, message := write_request(ctx, folderResourceParams(plan), "/api/add_folder", POST)
+	if code != 200 {
+		resp.Diagnostics.AddError(fmt.Sprintf("%d - %s", code, message), "")
+		return
+	}
+	plan.ID = types.Int64Value(id)
+	r.refresh(ctx, &plan, resp.Diagnostics.AddWarning)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *FolderResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state FolderResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	entries, code, message := get_folders(ctx)
+	if code != 200 {
+		resp.Diagnostics.AddError(fmt.Sprintf("%d - %s", code, message), "")
+		return
+	}
+	for _, entry := range entries {
+		if entry.ID == state.ID.ValueInt64() {
+			state.apply(entry, folderPaths(entries)[entry.ID])
+			resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+			return
+		}
+	}
+	resp.State.RemoveResource(ctx)
+}
+
+func (r *FolderResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan, state FolderResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	params := folderResourceParams(plan)
+	setParam(params, "folder_id", state.ID)
+	if plan.ParentID.IsNull() && !state.ParentID.IsNull() {
+		params["parent_folder_id"] = 0
+	}
+	if plan.Description.IsNull() && !state.Description.IsNull() {
+		params["description"] = ""
+	}
+	_, code, message := write_request(ctx, params, "/api/edit_folder", PUT)
+	if code != 200 {
+		resp.Diagnostics.AddError(fmt.Sprintf("%d - %s", code, message), "")
+		return
+	}
+	plan.ID = state.ID
+	r.refresh(ctx, &plan, resp.Diagnostics.AddWarning)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *FolderResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state FolderResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	params := make(map[string]any)
+	setParam(params, "folder_id", state.ID)
+	_, code, message := write_request(ctx, params, "/api/delete_folder", DELETE)
+	if code != 200 && code != http.StatusNotFound {
+		resp.Diagnostics.AddError(fmt.Sprintf("%d - %s", code, message), "")
+	}
+}
+
+func (r *FolderResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	id, err := strconv.ParseInt(req.ID, 10, 64)
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid Import ID", fmt.Sprintf("Expected a numeric folder ID, got: %q", req.ID))
+		return
+	}
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), id)...)
+}
+
+// refresh fills the computed path of model after a write. A failed listing
+// only warns since the folder itself was saved.
+func (r *FolderResource) refresh(ctx context.Context, model *FolderResourceModel, warn func(string, string)) {
+	model.Path = types.StringNull()
+	entries, code, message := get_folders(ctx)
+	if code != 200 {
+		warn("Unable to read folder path", fmt.Sprintf("%d - %s", code, message))
+		return
+	}
+	if path, ok := folderPaths(entries)[model.ID.ValueInt64()]; ok {
+		model.Path = types.StringValue(path)
+	}
+}
+
+func (model *FolderResourceModel) apply(entry folderEntry, folderPath string) {
+	model.Name = types.StringValue(entry.Name)
+	model.Description = refreshString(model.Description, types.StringValue(entry.Description))
+	model.ParentID = types.Int64Null()
+	if entry.ParentID != 0 {
+		model.ParentID = types.Int64Value(entry.ParentID)
+	}
+	model.Path = types.StringValue(folderPath)
+}
+
+func folderResourceParams(plan FolderResourceModel) map[string]any {
+	params := make(map[string]any)
+	setParam(params, "folder_name", plan.Name)
+	setParam(params, "parent_folder_id", plan.ParentID)
+	setParam(params, "description", plan.Description)
+	return params
+}
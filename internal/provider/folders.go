@@ -0,0 +1,298 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ datasource.DataSource = &Folders{}
+
+func folders() datasource.DataSource {
+	return &Folders{}
+}
+
+type Folders struct {
+	client *http.Client
+}
+
+type FoldersModel struct {
+	PathPrefix types.String         `tfsdk:"path_prefix"`
+	MaxResults types.Int64          `tfsdk:"max_results"`
+	Folders    []FolderSummaryModel `tfsdk:"folders"`
+	Paths      map[string]int64     `tfsdk:"paths"`
+}
+
+type FolderSummaryModel struct {
+	FolderID    types.Int64  `tfsdk:"folder_id"`
+	Name        types.String `tfsdk:"name"`
+	ParentID    types.Int64  `tfsdk:"parent_id"`
+	Description types.String `tfsdk:"description"`
+	Path        types.String `tfsdk:"path"`
+}
+
+// folderEntry is a folder as returned by get_folders. A ParentID of zero
+// marks a top level folder.
+type folderEntry struct {
+	ID          int64
+	Name        string
+	ParentID    int64
+	Description string
+}
+
+func (d *Folders) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_folders"
+}
+
+func (d *Folders) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Lists the folders in Securden along with their full paths.",
+
+		Attributes: map[string]schema.Attribute{
+			"path_prefix": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Only return folders whose path starts with this prefix, for example `Prod/Databases`.",
+			},
+			"max_results": maxResultsAttribute("Maximum number of folders to return, in path order. Every folder is still read to build the paths. Defaults to no limit."),
+			"folders": schema.ListNestedAttribute{
+				Computed:            true,
+				MarkdownDescription: "The matching folders.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"folder_id": schema.Int64Attribute{
+							Computed:            true,
+							MarkdownDescription: "Unique identifier of the folder.",
+						},
+						"name": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Name of the folder.",
+						},
+						"parent_id": schema.Int64Attribute{
+							Computed:            true,
+							MarkdownDescription: "ID of the parent folder, null for top level folders.",
+						},
+						"description": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Description of the folder.",
+						},
+						"path": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Full path of the folder, for example `Prod/Databases/Oracle`.",
+						},
+					},
+				},
+			},
+			"paths": schema.MapAttribute{
+				ElementType:         types.Int64Type,
+				Computed:            true,
+				MarkdownDescription: "A map of folder path to folder ID.",
+			},
+		},
+	}
+}
+
+func (d *Folders) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	client, ok := req.ProviderData.(*http.Client)
+	if !ok {
+		resp.Diagnostics.AddWarning(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *http.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+	d.client = client
+}
+
+func (d *Folders) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data FoldersModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	entries, code, message := get_folders(ctx)
+	if code != 200 {
+		resp.Diagnostics.AddError(fmt.Sprintf("%d - %s", code, message), "")
+		return
+	}
+	prefix := normalizeFolderPath(data.PathPrefix.ValueString())
+	paths := folderPaths(entries)
+	data.Folders = []FolderSummaryModel{}
+	for _, entry := range entries {
+		folderPath := paths[entry.ID]
+		if prefix != "" && folderPath != prefix && !strings.HasPrefix(folderPath, prefix+"/") {
+			continue
+		}
+		data.Folders = append(data.Folders, entry.summary(folderPath))
+	}
+	sort.Slice(data.Folders, func(i, j int) bool {
+		return data.Folders[i].Path.ValueString() < data.Folders[j].Path.ValueString()
+	})
+	// Paths are built from the parent links, so the whole listing is read and
+	// the cap is applied to the result.
+	if maxResults := int(data.MaxResults.ValueInt64()); maxResults > 0 && len(data.Folders) > maxResults {
+		data.Folders = data.Folders[:maxResults]
+	}
+	data.Paths = make(map[string]int64)
+	for _, folder := range data.Folders {
+		data.Paths[folder.Path.ValueString()] = folder.FolderID.ValueInt64()
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (entry folderEntry) summary(folderPath string) FolderSummaryModel {
+	summary := FolderSummaryModel{
+		FolderID:    types.Int64Value(entry.ID),
+		Name:        types.StringValue(entry.Name),
+		ParentID:    types.Int64Null(),
+		Description: types.StringValue(entry.Description),
+		Path:        types.StringValue(folderPath),
+	}
+	if entry.ParentID != 0 {
+		summary.ParentID = types.Int64Value(entry.ParentID)
+	}
+	return summary
+}
+
+// folderPaths builds the slash separated path of every folder from the
+// parent links. Cycles and dangling parents end the path at that folder.
+func folderPaths(entries []folderEntry) map[int64]string {
+	byID := make(map[int64]folderEntry, len(entries))
+	for _, entry := range entries {
+		byID[entry.ID] = entry
+	}
+	paths := make(map[int64]string, len(entries))
+	for _, entry := range entries {
+		names := []string{entry.Name}
+		seen := map[int64]bool{entry.ID: true}
+		parentID := entry.ParentID
+		for parentID != 0 && !seen[parentID] {
+			parent, ok := byID[parentID]
+			if !ok {
+				break
+			}
+			seen[parentID] = true
+			names = append([]string{parent.Name}, names...)
+			parentID = parent.ParentID
+		}
+		paths[entry.ID] = strings.Join(names, "/")
+	}
+	return paths
+}
+
+func normalizeFolderPath(folderPath string) string {
+	parts := strings.Split(folderPath, "/")
+	cleaned := make([]string, 0, len(parts))
+	for _, part := range parts {
+		if part = strings.TrimSpace(part); part != "" {
+			cleaned = append(cleaned, part)
+		}
+	}
+	return strings.Join(cleaned, "/")
+}
+
+// resolveFolderPath returns the folder at folderPath, matching exactly first
+// and falling back to a unique case-insensitive match.
+func resolveFolderPath(entries []folderEntry, folderPath string) (folderEntry, bool) {
+	folderPath = normalizeFolderPath(folderPath)
+	paths := folderPaths(entries)
+	var folded []folderEntry
+	for _, entry := range entries {
+		if paths[entry.ID] == folderPath {
+			return entry, true
+		}
+		if strings.EqualFold(paths[entry.ID], folderPath) {
+			folded = append(folded, entry)
+		}
+	}
+	if len(folded) == 1 {
+		return folded[0], true
+	}
+	return folderEntry{}, false
+}
+
+// setFolderParam resolves folderPath to a folder ID and stores it as the
+// folder_id request parameter. It is a no-op when folderPath is not set.
+func setFolderParam(ctx context.Context, params map[string]any, folderPath types.String, diags *diag.Diagnostics) {
+	if folderPath.IsNull() || folderPath.IsUnknown() {
+		return
+	}
+	entries, code, message := get_folders(ctx)
+	if code != 200 {
+		diags.AddAttributeError(path.Root("folder_path"), fmt.Sprintf("%d - %s", code, message), "Unable to list folders to resolve folder_path.")
+		return
+	}
+	entry, ok := resolveFolderPath(entries, folderPath.ValueString())
+	if !ok {
+		detail := fmt.Sprintf("No folder exists at path %q.", folderPath.ValueString())
+		if matches := closeFolderPaths(entries, folderPath.ValueString(), 5); len(matches) > 0 {
+			detail += " Did you mean one of: " + strings.Join(matches, ", ") + "?"
+		}
+		diags.AddAttributeError(path.Root("folder_path"), "Folder Not Found", detail)
+		return
+	}
+	params["folder_id"] = entry.ID
+}
+
+// closeFolderPaths returns up to limit existing paths that are a short edit
+// distance away from folderPath, closest first.
+func closeFolderPaths(entries []folderEntry, folderPath string, limit int) []string {
+	target := strings.ToLower(normalizeFolderPath(folderPath))
+	maxDistance := len(target) / 3
+	if maxDistance < 2 {
+		maxDistance = 2
+	}
+	type candidate struct {
+		path     string
+		distance int
+	}
+	var candidates []candidate
+	for _, candidatePath := range folderPaths(entries) {
+		distance := levenshtein(target, strings.ToLower(candidatePath))
+		if distance <= maxDistance {
+			candidates = append(candidates, candidate{candidatePath, distance})
+		}
+	}
+	sort.Slice(candidates, func(i, j int) bool {
+		if candidates[i].distance != candidates[j].distance {
+			return candidates[i].distance < candidates[j].distance
+		}
+		return candidates[i].path < candidates[j].path
+	})
+	matches := []string{}
+	for i := 0; i < len(candidates) && i < limit; i++ {
+		matches = append(matches, fmt.Sprintf("%q", candidates[i].path))
+	}
+	return matches
+}
+
+func levenshtein(a, b string) int {
+	source, target := []rune(a), []rune(b)
+	previous := make([]int, len(target)+1)
+	current := make([]int, len(target)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+	for i := 1; i <= len(source); i++ {
+		current[0] = i
+		for j := 1; j <= len(target); j++ {
+			cost := 1
+			if source[i-1] == target[j-1] {
+				cost = 0
+			}
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+		previous, current = current, previous
+	}
+	return previous[len(target)]
+}
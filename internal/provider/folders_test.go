@@ -0,0 +1,158 @@
+package provider
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var testFolders = []folderEntry{
+	{ID: 1, Name: "Prod"},
+	{ID: 2, Name: "Databases", ParentID: 1},
+	{ID: 3, Name: "Oracle", ParentID: 2},
+	{ID: 4, Name: "Dev"},
+	{ID: 5, Name: "databases", ParentID: 4},
+	{ID: 6, Name: "Shared", ParentID: 99},
+}
+
+func TestFolderPaths(t *testing.T) {
+	paths := folderPaths(testFolders)
+	want := map[int64]string{
+		1: "Prod",
+		2: "Prod/Databases",
+		3: "Prod/Databases/Oracle",
+		4: "Dev",
+		5: "Dev/databases",
+		6: "Shared",
+	}
+	for id, path := range want {
+		if paths[id] != path {
+			t.Errorf("path of folder %d = %q, want %q", id, paths[id], path)
+		}
+	}
+}
+
+func TestFolderPathsCycle(t *testing.T) {
+	paths := folderPaths([]folderEntry{
+		{ID: 1, Name: "A", ParentID: 2},
+		{ID: 2, Name: "B", ParentID: 1},
+	})
+	if paths[1] != "B/A" || paths[2] != "A/B" {
+		t.Errorf("paths = %v", paths)
+	}
+}
+
+func TestResolveFolderPath(t *testing.T) {
+	tests := []struct {
+		path   string
+		wantID int64
+		wantOK bool
+	}{
+		{"Prod/Databases/Oracle", 3, true},
+		{" /Prod / Databases/ ", 2, true},
+		{"prod/databases/oracle", 3, true},
+		{"Dev/databases", 5, true},
+		{"DEV/DATABASES", 5, true},
+		{"Prod/Oracle", 0, false},
+		{"", 0, false},
+	}
+	for _, test := range tests {
+		entry, ok := resolveFolderPath(testFolders, test.path)
+		if ok != test.wantOK || entry.ID != test.wantID {
+			t.Errorf("resolveFolderPath(%q) = %d, %t, want %d, %t", test.path, entry.ID, ok, test.wantID, test.wantOK)
+		}
+	}
+}
+
+func TestResolveFolderPathAmbiguousCase(t *testing.T) {
+	entries := []folderEntry{
+		{ID: 1, Name: "Prod"},
+		{ID: 2, Name: "PROD"},
+	}
+	if entry, ok := resolveFolderPath(entries, "PROD"); !ok || entry.ID != 2 {
+		t.Errorf("exact match = %d, %t, want 2, true", entry.ID, ok)
+	}
+	if _, ok := resolveFolderPath(entries, "prod"); ok {
+		t.Error("expected no match when several folders differ only by case")
+	}
+}
+
+func TestCloseFolderPaths(t *testing.T) {
+	matches := closeFolderPaths(testFolders, "Prod/Databses", 5)
+	if len(matches) == 0 || matches[0] != `"Prod/Databases"` {
+		t.Errorf("closeFolderPaths = %v", matches)
+	}
+	if matches := closeFolderPaths(testFolders, "Completely/Different/Path", 5); len(matches) != 0 {
+		t.Errorf("closeFolderPaths = %v, want none", matches)
+	}
+}
+
+func foldersServer(t *testing.T) *fakeServer {
+	return newFakeServer(t, map[string]fakeHandler{
+		"/secretsmanagement/get_folders": func(params map[string]any) any {
+			return map[string]any{"status_code": 200, "folders": []map[string]any{
+				{"folder_id": 10, "folder_name": "Prod"},
+				{"folder_id": 12, "folder_name": "DB", "parent_folder_id": 10},
+				{"folder_id": 13, "folder_name": "Web", "parent_folder_id": 10},
+			}}
+		},
+	})
+}
+
+func TestSetFolderParam(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("resolved", func(t *testing.T) {
+		foldersServer(t)
+		params := make(map[string]any)
+		var diags diag.Diagnostics
+		setFolderParam(ctx, params, types.StringValue(" prod/db/ "), &diags)
+		if diags.HasError() {
+			t.Fatal(diags)
+		}
+		if params["folder_id"] != int64(12) {
+			t.Errorf("folder_id = %v, want 12", params["folder_id"])
+		}
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		foldersServer(t)
+		params := make(map[string]any)
+		var diags diag.Diagnostics
+		setFolderParam(ctx, params, types.StringValue("Prod/Webb"), &diags)
+		if !diags.HasError() {
+			t.Fatal("expected an error for a missing folder")
+		}
+		if detail := diags.Errors()[0].Detail(); !strings.Contains(detail, `Did you mean one of: "Prod/Web"`) {
+			t.Errorf("error detail = %q, want the closest path suggested", detail)
+		}
+		if _, ok := params["folder_id"]; ok {
+			t.Errorf("folder_id = %v, want unset", params["folder_id"])
+		}
+	})
+
+	t.Run("not set", func(t *testing.T) {
+		fake := foldersServer(t)
+		params := make(map[string]any)
+		var diags diag.Diagnostics
+		setFolderParam(ctx, params, types.StringNull(), &diags)
+		if diags.HasError() || len(params) != 0 {
+			t.Errorf("params = %v, diags = %v, want nothing", params, diags)
+		}
+		if calls := fake.calls("/secretsmanagement/get_folders"); len(calls) != 0 {
+			t.Errorf("folders listed %d times, want none", len(calls))
+		}
+	})
+
+	t.Run("listing fails", func(t *testing.T) {
+		newFakeServer(t, nil)
+		var diags diag.Diagnostics
+		setFolderParam(ctx, make(map[string]any), types.StringValue("Prod/DB"), &diags)
+		if !diags.HasError() || !strings.HasPrefix(diags.Errors()[0].Summary(), "404 - ") {
+			t.Errorf("diags = %v, want the listing error", diags)
+		}
+	})
+}
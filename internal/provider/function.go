@@ -16,6 +16,7 @@ import (
 	"regexp"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-framework/attr"
@@ -41,6 +42,7 @@ func logger(data interface{}) error {
 
 func isServerReachable(serverURL string) bool {
 	client := createInsecureClient()
+	client.Timeout = 10 * time.Second
 	resp, err := client.Get(serverURL)
 	if err != nil {
 		return false
@@ -168,7 +170,6 @@ func createSecureClient(cert *x509.Certificate) *http.Client {
 }
 
 func createInsecureClient() *http.Client {
-	fmt.Sprintf("Creating insecure HTTP client")
 	tlsConfig := &tls.Config{
 		InsecureSkipVerify: true,
 	}
@@ -199,11 +200,19 @@ func readPEMFile(filePath string) (*x509.Certificate, error) {
 	return cert, nil
 }
 
-func raise_request(params map[string]any, apiURL string, method string) ([]byte, error) {
+func raise_request(ctx context.Context, params map[string]any, apiURL string, method string) ([]byte, error) {
 	pattern := regexp.MustCompile("^https")
 	var client *http.Client
 	var err error
 
+	connectivity := SecurdenConnectivity
+	if err := connectivity.check(); err != nil {
+		return nil, err
+	}
+	if connectivity.offline {
+		return nil, fmt.Errorf("the Securden server %s is not reachable, the provider is running in offline mode", SecurdenServerURL)
+	}
+
 	if pattern.MatchString(SecurdenServerURL) {
 		if len(SecurdenCertificate) == 0 {
 			cert, certErr := fetchSSLCertificate(SecurdenServerURL)
@@ -241,7 +250,7 @@ func raise_request(params map[string]any, apiURL string, method string) ([]byte,
 				if v != "" {
 					q.Add(key, v)
 				}
-			case int, int64, float64:
+			case int, int64, json.Number:
 				q.Add(key, fmt.Sprintf("%v", v))
 			case bool:
 				q.Add(key, strconv.FormatBool(v))
@@ -275,10 +284,10 @@ func raise_request(params map[string]any, apiURL string, method string) ([]byte,
 			return nil, fmt.Errorf("failed to serialize request body: %v", err)
 		}
 
-		apiRequest, err = http.NewRequest(method, reqURL.String(), bytes.NewBuffer(requestBody))
+		apiRequest, err = http.NewRequestWithContext(ctx, method, reqURL.String(), bytes.NewBuffer(requestBody))
 		apiRequest.Header.Set("Content-Type", "application/json")
 	} else {
-		apiRequest, err = http.NewRequest(method, reqURL.String(), nil)
+		apiRequest, err = http.NewRequestWithContext(ctx, method, reqURL.String(), nil)
 	}
 
 	if err != nil {
@@ -304,8 +313,51 @@ func raise_request(params map[string]any, apiURL string, method string) ([]byte,
 	return body, nil
 }
 
+// decodeJSON decodes API responses keeping numbers as json.Number, so that
+// 13-digit Securden IDs are not rounded through float64.
+func decodeJSON(body []byte, v any) error {
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+	return decoder.Decode(v)
+}
+
 func get_account(ctx context.Context, account_id int64, account_name, account_title, account_type, ticket_id, reason string) (AccountModel, int, string) {
 	var account AccountModel
+	response, code, message := get_account_response(ctx, account_id, account_name, account_title, account_type, ticket_id, reason)
+	if code != 200 {
+		return account, code, message
+	}
+	account = accountFromResponse(response)
+	return account, code, message
+}
+
+// get_account_file fetches an account and decodes the file attached to it.
+func get_account_file(ctx context.Context, account_id int64, account_name, account_title, ticket_id, reason string) (accountFile, int, string) {
+	response, code, message := get_account_response(ctx, account_id, account_name, account_title, "", ticket_id, reason)
+	if code != 200 {
+		return accountFile{}, code, message
+	}
+	file, err := accountFileFromResponse(response)
+	if err != nil {
+		return accountFile{}, 500, err.Error()
+	}
+	return file, code, message
+}
+
+// refresh_account fetches an account by ID straight from the server, skipping
+// the response cache, for resource reads that must see the current state.
+func refresh_account(ctx context.Context, account_id int64) (AccountModel, int, string) {
+	params := make(map[string]any)
+	setParam(params, "account_id", types.Int64Value(account_id))
+	response, code, message := account_response(ctx, params, raise_request)
+	if code != 200 {
+		return AccountModel{}, code, message
+	}
+	return accountFromResponse(response), code, message
+}
+
+// get_account_response returns the decoded get_account response.
+func get_account_response(ctx context.Context, account_id int64, account_name, account_title, account_type, ticket_id, reason string) (map[string]interface{}, int, string) {
 	params := make(map[string]any)
 	if account_id != 0 {
 		setParam(params, "account_id", types.Int64Value(account_id))
@@ -315,95 +367,398 @@ func get_account(ctx context.Context, account_id int64, account_name, account_ti
 	setParam(params, "account_type", types.StringValue(account_type))
 	setParam(params, "ticket_id", types.StringValue(ticket_id))
 	setParam(params, "reason", types.StringValue(reason))
-	body, err := raise_request(params, "/secretsmanagement/get_account", GET)
+	return account_response(ctx, params, cached_request)
+}
+
+func account_response(ctx context.Context, params map[string]any, request func(context.Context, map[string]any, string, string) ([]byte, error)) (map[string]interface{}, int, string) {
+	body, err := request(ctx, params, "/secretsmanagement/get_account", GET)
 	if err != nil {
-		return account, 500, fmt.Sprintf("Error in API call: %v", err)
+		return nil, 500, fmt.Sprintf("Error in API call: %v", err)
 	}
 	var response map[string]interface{}
-	err = json.Unmarshal(body, &response)
+	err = decodeJSON(body, &response)
 	if err != nil {
-		return account, 500, fmt.Sprintf("Error parsing response JSON: %v", err)
+		return nil, 500, fmt.Sprintf("Error parsing response JSON: %v", err)
 	}
-	statusCode, ok := response["status_code"].(float64)
+	statusCode, ok := int64Value(response["status_code"])
 	if !ok {
-		return account, 500, "Missing or invalid status_code in response"
+		return nil, 500, "Missing or invalid status_code in response"
 	}
 	if statusCode != 200 {
 		if errMsg, exists := response["error"].(map[string]interface{}); exists {
 			if msg, ok := errMsg["message"].(string); ok {
-				return account, int(statusCode), msg
+				return nil, int(statusCode), msg
 			}
 		}
 		if msg, ok := response["message"].(string); ok {
-			return account, int(statusCode), msg
+			return nil, int(statusCode), msg
 		}
-		return account, int(statusCode), "Unknown error"
+		return nil, int(statusCode), "Unknown error"
 	}
+	return response, int(statusCode), "Success"
+}
+
+// accountFromResponse maps a get_account response onto the typed attributes of
+// AccountModel while keeping every field in the raw account map.
+func accountFromResponse(response map[string]interface{}) AccountModel {
+	var account AccountModel
 	accountData := make(map[string]attr.Value)
+	additionalFields := make(map[string]attr.Value)
 	for key, value := range response {
+		if key == "status_code" || key == "message" {
+			continue
+		}
 		switch v := value.(type) {
-		case string:
-			accountData[key] = types.StringValue(v)
-		case float64:
-			accountData[key] = types.StringValue(fmt.Sprintf("%v", v))
 		case map[string]interface{}:
-			nestedData := make(map[string]attr.Value)
+			encoded, _ := json.Marshal(v)
+			accountData[key] = types.StringValue(string(encoded))
 			for nestedKey, nestedValue := range v {
-				if nestedStr, ok := nestedValue.(string); ok {
-					nestedData[nestedKey] = types.StringValue(nestedStr)
-				} else {
-					nestedData[nestedKey] = types.StringValue(fmt.Sprintf("%v", nestedValue))
+				if key == "additional_fields" {
+					additionalFields[nestedKey] = types.StringValue(stringifyValue(nestedValue))
+				}
+				if _, exists := response[nestedKey]; !exists {
+					accountData[nestedKey] = types.StringValue(stringifyValue(nestedValue))
 				}
 			}
-			accountData[key], _ = types.MapValue(types.StringType, nestedData)
 		default:
-			accountData[key] = types.StringValue(fmt.Sprintf("%v", value))
+			accountData[key] = types.StringValue(stringifyValue(value))
+			if _, standard := accountStandardFields[key]; !standard {
+				additionalFields[key] = types.StringValue(stringifyValue(value))
+			}
 		}
 	}
 	account.Account, _ = types.MapValue(types.StringType, accountData)
-	return account, int(statusCode), "Success"
+	account.AdditionalFields, _ = types.MapValue(types.StringType, additionalFields)
+
+	account.AccountID = types.Int64Null()
+	if id, ok := int64Value(response["account_id"]); ok {
+		account.AccountID = types.Int64Value(id)
+	}
+	account.AccountName = optionalString(response, "account_name")
+	account.AccountTitle = optionalString(response, "account_title")
+	account.AccountType = optionalString(response, "account_type")
+	account.Address = optionalString(response, "address")
+	account.Password = optionalString(response, "password")
+	account.PrivateKey = optionalString(response, "private_key")
+
+	account.Port = types.Int64Null()
+	for _, key := range []string{"port", "sql_server_port", "mysql_port", "oracle_port"} {
+		if port, ok := int64Value(response[key]); ok {
+			account.Port = types.Int64Value(port)
+			break
+		}
+	}
+
+	tags := []attr.Value{}
+	for _, tag := range tagsFromValue(response["tags"]) {
+		tags = append(tags, types.StringValue(tag))
+	}
+	account.Tags, _ = types.ListValue(types.StringType, tags)
+	account.ExpiresAt, account.DaysUntilExpiration = expirationAttributes(stringifyValue(response["account_expiration_date"]), time.Now().UTC())
+	return account
+}
+
+// tagsFromValue accepts tags either as a JSON list or as the comma separated
+// string used by the add and edit account APIs.
+func tagsFromValue(value interface{}) []string {
+	var tags []string
+	switch v := value.(type) {
+	case []interface{}:
+		for _, tag := range v {
+			tags = append(tags, stringifyValue(tag))
+		}
+	case string:
+		for _, tag := range strings.Split(v, ",") {
+			if tag = strings.TrimSpace(tag); tag != "" {
+				tags = append(tags, tag)
+			}
+		}
+	}
+	return tags
+}
+
+func boolValue(value interface{}) (bool, bool) {
+	switch v := value.(type) {
+	case bool:
+		return v, true
+	case string:
+		parsed, err := strconv.ParseBool(strings.TrimSpace(v))
+		return parsed, err == nil
+	}
+	return false, false
+}
+
+func stringifyValue(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case json.Number:
+		return v.String()
+	case map[string]interface{}, []interface{}:
+		encoded, _ := json.Marshal(v)
+		return string(encoded)
+	default:
+		return fmt.Sprintf("%v", v)
+	}
+}
+
+func int64Value(value interface{}) (int64, bool) {
+	switch v := value.(type) {
+	case json.Number:
+		parsed, err := v.Int64()
+		return parsed, err == nil
+	case string:
+		parsed, err := strconv.ParseInt(strings.TrimSpace(v), 10, 64)
+		return parsed, err == nil
+	}
+	return 0, false
+}
+
+func optionalString(response map[string]interface{}, key string) types.String {
+	value, ok := response[key]
+	if !ok || value == nil {
+		return types.StringNull()
+	}
+	return types.StringValue(stringifyValue(value))
 }
 
-func get_accounts(ctx context.Context, params map[string]any) (map[string]map[string]string, int, string) {
+// get_accounts returns the retrieved accounts keyed by account ID along with
+// the per-ID errors reported by the server.
+func get_accounts(ctx context.Context, params map[string]any) (map[string]map[string]string, map[string]string, int, string) {
 	var accounts_data = make(map[string]any)
 	var null map[string]map[string]string
 
-	body, err := raise_request(params, "/secretsmanagement/get_accounts", POST)
+	body, err := cached_request(ctx, params, "/secretsmanagement/get_accounts", POST)
 	if err != nil {
-		return null, 500, fmt.Sprintf("Error in API call: %v", err)
+		return null, nil, 500, fmt.Sprintf("Error in API call: %v", err)
 	}
 
-	err = json.Unmarshal(body, &accounts_data)
+	err = decodeJSON(body, &accounts_data)
 	if err != nil {
-		return null, 500, fmt.Sprintf("Error parsing response: %v", err)
+		return null, nil, 500, fmt.Sprintf("Error parsing response: %v", err)
+	}
+
+	if statusCode, ok := int64Value(accounts_data["status_code"]); ok && statusCode != 200 {
+		return null, nil, int(statusCode), responseErrorMessage(accounts_data)
 	}
 
 	processedAccounts := make(map[string]map[string]string)
+	accountErrors := make(map[string]string)
+
+	if serverErrors, ok := accounts_data["errors"].(map[string]any); ok {
+		for key, value := range serverErrors {
+			accountErrors[key] = stringifyValue(value)
+		}
+	}
 
 	for key, value := range accounts_data {
+		if key == "status_code" || key == "message" || key == "errors" {
+			continue
+		}
 		accountMap, ok := value.(map[string]any)
 		if !ok {
+			if value == nil {
+				accountErrors[key] = "Account not found"
+			} else {
+				accountErrors[key] = stringifyValue(value)
+			}
+			continue
+		}
+		if statusCode, ok := int64Value(accountMap["status_code"]); ok && statusCode != 200 {
+			accountErrors[key] = fmt.Sprintf("%d - %s", statusCode, responseErrorMessage(accountMap))
+			continue
+		}
+		if _, ok := accountMap["error"]; ok {
+			accountErrors[key] = responseErrorMessage(accountMap)
 			continue
 		}
 
 		processedEntry := make(map[string]string)
 		for k, v := range accountMap {
-			if v == nil {
-				processedEntry[k] = ""
-			} else {
-				processedEntry[k] = fmt.Sprintf("%v", v)
-			}
+			processedEntry[k] = stringifyValue(v)
 		}
 
 		processedAccounts[key] = processedEntry
 	}
 
-	return processedAccounts, 200, "Success"
+	return processedAccounts, accountErrors, 200, "Success"
+}
+
+// responseErrorMessage extracts the error message of a Securden error
+// response, which is either error.message or message.
+func responseErrorMessage(response map[string]any) string {
+	if errMsg, ok := response["error"].(map[string]any); ok {
+		if msg, ok := errMsg["message"].(string); ok {
+			return msg
+		}
+	}
+	if msg, ok := response["error"].(string); ok {
+		return msg
+	}
+	if msg, ok := response["message"].(string); ok {
+		return msg
+	}
+	return "Unknown error"
+}
+
+// get_accounts_in_chunks splits accountIDs into chunks of chunkSize, fetches
+// the chunks concurrently (bounded by max_parallel_requests) and merges the
+// results. The second return value maps every requested ID that could not be
+// retrieved to the reason it is missing.
+func get_accounts_in_chunks(ctx context.Context, accountIDs []int64, chunkSize int) (map[string]map[string]string, map[string]string) {
+	if chunkSize < 1 {
+		chunkSize = defaultAccountsChunkSize
+	}
+	parallelism := SecurdenMaxParallelRequests
+	if parallelism < 1 {
+		parallelism = defaultMaxParallelRequests
+	}
+
+	accounts := make(map[string]map[string]string)
+	failures := make(map[string]string)
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	semaphore := make(chan struct{}, parallelism)
+
+	for start := 0; start < len(accountIDs); start += chunkSize {
+		end := start + chunkSize
+		if end > len(accountIDs) {
+			end = len(accountIDs)
+		}
+		chunk := accountIDs[start:end]
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			semaphore <- struct{}{}
+			defer func() { <-semaphore }()
+
+			params := make(map[string]any)
+			params["account_ids"] = chunk
+			chunkAccounts, chunkErrors, code, message := get_accounts(ctx, params)
+
+			mu.Lock()
+			defer mu.Unlock()
+			if code != 200 {
+				for _, id := range chunk {
+					failures[strconv.FormatInt(id, 10)] = fmt.Sprintf("%d - %s", code, message)
+				}
+				return
+			}
+			for key, account := range chunkAccounts {
+				accounts[key] = account
+			}
+			for key, reason := range chunkErrors {
+				failures[key] = reason
+			}
+		}()
+	}
+	wg.Wait()
+
+	for _, id := range accountIDs {
+		key := strconv.FormatInt(id, 10)
+		if _, ok := accounts[key]; ok {
+			continue
+		}
+		if _, ok := failures[key]; !ok {
+			failures[key] = "Account not returned by Securden"
+		}
+	}
+	return accounts, failures
+}
+
+// search_accounts returns up to maxResults accounts accepted by keep.
+func search_accounts(ctx context.Context, params map[string]any, maxResults int, keep func(AccountSummaryModel) bool) ([]AccountSummaryModel, int, string) {
+	return collect(ctx, func(ctx context.Context, page pageRequest) (pageResult[AccountSummaryModel], int, string) {
+		return search_accounts_page(ctx, params, page)
+	}, maxResults, keep)
+}
+
+func search_accounts_page(ctx context.Context, params map[string]any, page pageRequest) (pageResult[AccountSummaryModel], int, string) {
+	result := pageResult[AccountSummaryModel]{Total: -1}
+	body, err := cached_request(ctx, pagedParams(params, page), "/secretsmanagement/search_accounts", GET)
+	if err != nil {
+		return result, 500, fmt.Sprintf("Error in API call: %v", err)
+	}
+	var response map[string]any
+	err = decodeJSON(body, &response)
+	if err != nil {
+		return result, 500, fmt.Sprintf("Error parsing response: %v", err)
+	}
+	if statusCode, ok := int64Value(response["status_code"]); ok && statusCode != 200 {
+		if msg, ok := response["message"].(string); ok {
+			return result, int(statusCode), msg
+		}
+		return result, int(statusCode), "Unknown error"
+	}
+	entries, _ := response["accounts"].([]any)
+	result.Items = make([]AccountSummaryModel, 0, len(entries))
+	for _, entry := range entries {
+		accountMap, ok := entry.(map[string]any)
+		if !ok {
+			continue
+		}
+		result.Items = append(result.Items, accountSummaryFromResponse(accountMap))
+	}
+	setPageInfo(&result, response)
+	return result, 200, "Success"
+}
+
+// pagedParams copies params and adds the paging parameters of page, so that
+// concurrent page requests do not share the same map.
+func pagedParams(params map[string]any, page pageRequest) map[string]any {
+	paged := make(map[string]any, len(params)+2)
+	for key, value := range params {
+		paged[key] = value
+	}
+	paged["limit"] = page.Limit
+	if page.Cursor != "" {
+		paged["cursor"] = page.Cursor
+	} else {
+		paged["offset"] = page.Offset
+	}
+	return paged
+}
+
+func setPageInfo[T any](result *pageResult[T], response map[string]any) {
+	if total, ok := int64Value(response["total_count"]); ok {
+		result.Total = int(total)
+	}
+	if cursor, ok := response["next_cursor"].(string); ok {
+		result.NextCursor = cursor
+	}
+}
+
+func accountSummaryFromResponse(accountMap map[string]any) AccountSummaryModel {
+	summary := AccountSummaryModel{
+		AccountID:       types.Int64Null(),
+		AccountName:     optionalString(accountMap, "account_name"),
+		AccountTitle:    optionalString(accountMap, "account_title"),
+		AccountType:     optionalString(accountMap, "account_type"),
+		FolderID:        types.Int64Null(),
+		PersonalAccount: types.BoolNull(),
+		Tags:            []types.String{},
+	}
+	if id, ok := int64Value(accountMap["account_id"]); ok {
+		summary.AccountID = types.Int64Value(id)
+	}
+	if folderID, ok := int64Value(accountMap["folder_id"]); ok {
+		summary.FolderID = types.Int64Value(folderID)
+	}
+	if personal, ok := boolValue(accountMap["personal_account"]); ok {
+		summary.PersonalAccount = types.BoolValue(personal)
+	}
+	for _, tag := range tagsFromValue(accountMap["tags"]) {
+		summary.Tags = append(summary.Tags, types.StringValue(tag))
+	}
+	return summary
 }
 
 func add_account_function(ctx context.Context, params map[string]any) (AddAccountModel, int, string) {
 	var account AddAccountModel
-	body, err := raise_request(params, "/api/add_account", POST)
+	defer invalidate_cache()
+	body, err := raise_request(ctx, params, "/api/add_account", POST)
 	if err != nil {
 		return account, 500, fmt.Sprintf("Error in API call: %v", err)
 	}
@@ -418,7 +773,7 @@ func add_account_function(ctx context.Context, params map[string]any) (AddAccoun
 		} `json:"error"`
 	}
 
-	err = json.Unmarshal(body, &response)
+	err = decodeJSON(body, &response)
 	if err != nil {
 		return account, 500, fmt.Sprintf("Failed to parse response: %v", err)
 	}
@@ -436,13 +791,14 @@ func add_account_function(ctx context.Context, params map[string]any) (AddAccoun
 
 func delete_accounts_function(ctx context.Context, params map[string]any) (DeleteAccountsModel, int, string) {
 	var account DeleteAccountsModel
-	body, err := raise_request(params, "/api/delete_accounts", DELETE)
+	defer invalidate_cache()
+	body, err := raise_request(ctx, params, "/api/delete_accounts", DELETE)
 	if err != nil {
 		return account, 500, fmt.Sprintf("Error in API call: %v", err)
 	}
 
 	var response map[string]any
-	err = json.Unmarshal(body, &response)
+	err = decodeJSON(body, &response)
 	if err != nil {
 		return account, 500, fmt.Sprintf("Error parsing response: %v", err)
 	}
@@ -454,8 +810,8 @@ func delete_accounts_function(ctx context.Context, params map[string]any) (Delet
 	if deletedIDs, ok := response["IDs deleted successfully"].([]any); ok {
 		var idList []types.Int64
 		for _, id := range deletedIDs {
-			if idFloat, ok := id.(float64); ok {
-				idList = append(idList, types.Int64Value(int64(idFloat)))
+			if idValue, ok := int64Value(id); ok {
+				idList = append(idList, types.Int64Value(idValue))
 			}
 		}
 		account.DeletedAccounts = idList
@@ -466,7 +822,8 @@ func delete_accounts_function(ctx context.Context, params map[string]any) (Delet
 
 func edit_account_function(ctx context.Context, params map[string]any) (EditAccountModel, int, string) {
 	var account EditAccountModel
-	body, err := raise_request(params, "/api/edit_account", PUT)
+	defer invalidate_cache()
+	body, err := raise_request(ctx, params, "/api/edit_account", PUT)
 	if err != nil {
 		return account, 500, fmt.Sprintf("Error in API call: %v", err)
 	}
@@ -480,7 +837,7 @@ func edit_account_function(ctx context.Context, params map[string]any) (EditAcco
 		} `json:"error"`
 	}
 
-	err = json.Unmarshal(body, &response)
+	err = decodeJSON(body, &response)
 	if err != nil {
 		return account, 500, fmt.Sprintf("Failed to parse response: %v", err)
 	}
@@ -494,3 +851,181 @@ func edit_account_function(ctx context.Context, params map[string]any) (EditAcco
 	account.Message = types.StringValue(response.Message)
 	return account, response.StatusCode, response.Message
 }
+
+func get_folders(ctx context.Context) ([]folderEntry, int, string) {
+	return get_listing(ctx, map[string]any{}, "/secretsmanagement/get_folders", "folders", 0, nil, raise_request, func(folderMap map[string]any) folderEntry {
+		folder := folderEntry{
+			Name:        stringifyValue(folderMap["folder_name"]),
+			Description: stringifyValue(folderMap["description"]),
+		}
+		folder.ID, _ = int64Value(folderMap["folder_id"])
+		folder.ParentID, _ = int64Value(folderMap["parent_folder_id"])
+		return folder
+	})
+}
+
+func get_users(ctx context.Context) ([]userEntry, int, string) {
+	return get_listing(ctx, map[string]any{}, "/secretsmanagement/get_users", "users", 0, nil, cached_request, func(userMap map[string]any) userEntry {
+		user := userEntry{
+			Username:  stringifyValue(userMap["username"]),
+			Email:     stringifyValue(userMap["email"]),
+			FirstName: stringifyValue(userMap["first_name"]),
+			LastName:  stringifyValue(userMap["last_name"]),
+			Status:    stringifyValue(userMap["status"]),
+		}
+		user.ID, _ = int64Value(userMap["user_id"])
+		return user
+	})
+}
+
+// get_user_groups bypasses the response cache since group membership is
+// managed by the securden_user_group resource.
+func get_user_groups(ctx context.Context) ([]userGroupEntry, int, string) {
+	return get_listing(ctx, map[string]any{}, "/secretsmanagement/get_user_groups", "user_groups", 0, nil, raise_request, func(groupMap map[string]any) userGroupEntry {
+		group := userGroupEntry{
+			Name:        stringifyValue(groupMap["group_name"]),
+			Description: stringifyValue(groupMap["description"]),
+			Source:      stringifyValue(groupMap["source"]),
+			MemberIDs:   []int64{},
+		}
+		group.ID, _ = int64Value(groupMap["group_id"])
+		members, _ := groupMap["member_ids"].([]any)
+		for _, member := range members {
+			if memberID, ok := int64Value(member); ok {
+				group.MemberIDs = append(group.MemberIDs, memberID)
+			}
+		}
+		return group
+	})
+}
+
+// get_listing pages through a listing endpoint filtered by params, converting
+// every object under itemsKey with parse and returning up to maxResults items
+// accepted by keep.
+func get_listing[T any](ctx context.Context, params map[string]any, apiURL string, itemsKey string, maxResults int, keep func(T) bool, request func(context.Context, map[string]any, string, string) ([]byte, error), parse func(map[string]any) T) ([]T, int, string) {
+	return collect(ctx, func(ctx context.Context, page pageRequest) (pageResult[T], int, string) {
+		result := pageResult[T]{Total: -1}
+		body, err := request(ctx, pagedParams(params, page), apiURL, GET)
+		if err != nil {
+			return result, 500, fmt.Sprintf("Error in API call: %v", err)
+		}
+		var response map[string]any
+		err = decodeJSON(body, &response)
+		if err != nil {
+			return result, 500, fmt.Sprintf("Error parsing response: %v", err)
+		}
+		if statusCode, ok := int64Value(response["status_code"]); ok && statusCode != 200 {
+			return result, int(statusCode), responseErrorMessage(response)
+		}
+		entries, _ := response[itemsKey].([]any)
+		for _, entry := range entries {
+			if entryMap, ok := entry.(map[string]any); ok {
+				result.Items = append(result.Items, parse(entryMap))
+			}
+		}
+		setPageInfo(&result, response)
+		return result, 200, "Success"
+	}, maxResults, keep)
+}
+
+// write_request sends a write request and returns the ID reported by the
+// server, if any.
+func write_request(ctx context.Context, params map[string]any, apiURL string, method string) (int64, int, string) {
+	defer invalidate_cache()
+	body, err := raise_request(ctx, params, apiURL, method)
+	if err != nil {
+		return 0, 500, fmt.Sprintf("Error in API call: %v", err)
+	}
+	var response struct {
+		ID         int64  `json:"ID"`
+		StatusCode int    `json:"status_code"`
+		Message    string `json:"message"`
+		Error      struct {
+			Code    interface{} `json:"code"`
+			Message string      `json:"message"`
+		} `json:"error"`
+	}
+	err = decodeJSON(body, &response)
+	if err != nil {
+		return 0, 500, fmt.Sprintf("Failed to parse response: %v", err)
+	}
+	if response.StatusCode != 200 && response.StatusCode != 0 {
+		errorMessage := response.Message
+		if response.Error.Message != "" {
+			errorMessage = response.Error.Message
+		}
+		return 0, response.StatusCode, errorMessage
+	}
+	return response.ID, 200, response.Message
+}
+
+// get_shares lists the users and user groups an account or folder is shared
+// with. Like get_folders it bypasses the response cache so reads right after
+// a share change see the new permissions.
+func get_shares(ctx context.Context, params map[string]any) ([]shareGrant, int, string) {
+	body, err := raise_request(ctx, params, "/secretsmanagement/get_shares", GET)
+	if err != nil {
+		return nil, 500, fmt.Sprintf("Error in API call: %v", err)
+	}
+	var response map[string]any
+	err = decodeJSON(body, &response)
+	if err != nil {
+		return nil, 500, fmt.Sprintf("Error parsing response: %v", err)
+	}
+	if statusCode, ok := int64Value(response["status_code"]); ok && statusCode != 200 {
+		return nil, int(statusCode), responseErrorMessage(response)
+	}
+	grants := []shareGrant{}
+	entries, _ := response["shares"].([]any)
+	for _, entry := range entries {
+		shareMap, ok := entry.(map[string]any)
+		if !ok {
+			continue
+		}
+		grant := shareGrant{
+			PrincipalType: stringifyValue(shareMap["principal_type"]),
+			Permission:    strings.ToLower(stringifyValue(shareMap["permission"])),
+		}
+		grant.PrincipalID, _ = int64Value(shareMap["principal_id"])
+		grants = append(grants, grant)
+	}
+	return grants, 200, "Success"
+}
+
+func get_account_types(ctx context.Context) ([]accountTypeEntry, int, string) {
+	return get_listing(ctx, map[string]any{}, "/secretsmanagement/get_account_types", "account_types", 0, nil, cached_request, func(typeMap map[string]any) accountTypeEntry {
+		accountType := accountTypeEntry{
+			Name:     stringifyValue(typeMap["type_name"]),
+			Category: stringifyValue(typeMap["category"]),
+		}
+		fields, _ := typeMap["fields"].([]any)
+		for _, field := range fields {
+			fieldMap, ok := field.(map[string]any)
+			if !ok {
+				continue
+			}
+			entry := accountTypeField{
+				Name:  stringifyValue(fieldMap["name"]),
+				Label: stringifyValue(fieldMap["label"]),
+			}
+			entry.Required, _ = boolValue(fieldMap["required"])
+			entry.Sensitive, _ = boolValue(fieldMap["secret"])
+			accountType.Fields = append(accountType.Fields, entry)
+		}
+		return accountType
+	})
+}
+
+func get_expiring_accounts(ctx context.Context, params map[string]any, keep func(expiringAccountEntry) bool) ([]expiringAccountEntry, int, string) {
+	return get_listing(ctx, params, "/secretsmanagement/get_expiring_accounts", "accounts", 0, keep, cached_request, func(accountMap map[string]any) expiringAccountEntry {
+		account := expiringAccountEntry{
+			AccountName:    stringifyValue(accountMap["account_name"]),
+			AccountTitle:   stringifyValue(accountMap["account_title"]),
+			AccountType:    stringifyValue(accountMap["account_type"]),
+			ExpirationDate: stringifyValue(accountMap["account_expiration_date"]),
+		}
+		account.AccountID, _ = int64Value(accountMap["account_id"])
+		account.FolderID, _ = int64Value(accountMap["folder_id"])
+		return account
+	})
+}
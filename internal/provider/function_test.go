@@ -0,0 +1,51 @@
+package provider
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestDecodeJSONKeepsIntegersExact(t *testing.T) {
+	var response map[string]any
+	if err := decodeJSON([]byte(`{"account_id": 2000000001800, "ids": [1234567890123], "ratio": 0.5}`), &response); err != nil {
+		t.Fatal(err)
+	}
+	id, ok := response["account_id"].(json.Number)
+	if !ok {
+		t.Fatalf("account_id decoded as %T, want json.Number", response["account_id"])
+	}
+	if id.String() != "2000000001800" {
+		t.Errorf("account_id = %s, want 2000000001800", id)
+	}
+	if value, ok := int64Value(response["account_id"]); !ok || value != 2000000001800 {
+		t.Errorf("int64Value(account_id) = %d, %t", value, ok)
+	}
+	ids, _ := response["ids"].([]any)
+	if len(ids) != 1 || ids[0].(json.Number).String() != "1234567890123" {
+		t.Errorf("ids = %v", response["ids"])
+	}
+	if response["ratio"].(json.Number).String() != "0.5" {
+		t.Errorf("ratio = %v", response["ratio"])
+	}
+}
+
+func TestDecodeJSONStruct(t *testing.T) {
+	var response struct {
+		ID         int64  `json:"ID"`
+		StatusCode int    `json:"status_code"`
+		Message    string `json:"message"`
+	}
+	if err := decodeJSON([]byte(`{"ID": 2000000001800, "status_code": 200, "message": "Success"}`), &response); err != nil {
+		t.Fatal(err)
+	}
+	if response.ID != 2000000001800 || response.StatusCode != 200 || response.Message != "Success" {
+		t.Errorf("unexpected response %+v", response)
+	}
+}
+
+func TestDecodeJSONInvalid(t *testing.T) {
+	var response map[string]any
+	if err := decodeJSON([]byte(`<html>Bad Gateway</html>`), &response); err == nil {
+		t.Error("expected an error for a non JSON body")
+	}
+}
@@ -0,0 +1,159 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+)
+
+// fakeHandler answers a Securden API call with the value encoded as the JSON
+// response body.
+type fakeHandler func(params map[string]any) any
+
+type fakeRequest struct {
+	Method string
+	Path   string
+	Params map[string]any
+}
+
+// fakeServer is a stub of the Securden API. Handlers are looked up by request
+// path and receive the query string and JSON body parameters.
+type fakeServer struct {
+	mu       sync.Mutex
+	handlers map[string]fakeHandler
+	requests []fakeRequest
+}
+
+// newFakeServer starts a stub server and points the provider at it for the
+// duration of the test.
+func newFakeServer(t *testing.T, handlers map[string]fakeHandler) *fakeServer {
+	t.Helper()
+	fake := &fakeServer{handlers: handlers}
+	server := httptest.NewServer(http.HandlerFunc(fake.serve))
+	t.Cleanup(server.Close)
+
+	serverURL, authToken, certificate := SecurdenServerURL, SecurdenAuthToken, SecurdenCertificate
+	connectivity, cache, offlineCache := SecurdenConnectivity, SecurdenCache, SecurdenOfflineCache
+	parallelism, warningDays := SecurdenMaxParallelRequests, SecurdenExpirationWarningDays
+	t.Cleanup(func() {
+		SecurdenServerURL, SecurdenAuthToken, SecurdenCertificate = serverURL, authToken, certificate
+		SecurdenConnectivity, SecurdenCache, SecurdenOfflineCache = connectivity, cache, offlineCache
+		SecurdenMaxParallelRequests, SecurdenExpirationWarningDays = parallelism, warningDays
+	})
+	SecurdenServerURL = server.URL
+	SecurdenAuthToken = "test-token"
+	SecurdenCertificate = ""
+	SecurdenConnectivity = newConnectivityState(true)
+	SecurdenCache = nil
+	SecurdenOfflineCache = nil
+	SecurdenMaxParallelRequests = defaultMaxParallelRequests
+	SecurdenExpirationWarningDays = defaultExpirationWarningDays
+	return fake
+}
+
+func (f *fakeServer) serve(w http.ResponseWriter, r *http.Request) {
+	params := make(map[string]any)
+	for key, values := range r.URL.Query() {
+		if len(values) == 1 {
+			params[key] = values[0]
+		} else {
+			params[key] = values
+		}
+	}
+	if body, _ := io.ReadAll(r.Body); len(body) > 0 {
+		decodeJSON(body, &params)
+	}
+	f.mu.Lock()
+	f.requests = append(f.requests, fakeRequest{Method: r.Method, Path: r.URL.Path, Params: params})
+	handler, ok := f.handlers[r.URL.Path]
+	f.mu.Unlock()
+	w.Header().Set("Content-Type", "application/json")
+	if !ok {
+		json.NewEncoder(w).Encode(map[string]any{"status_code": 404, "message": "Not found: " + r.URL.Path})
+		return
+	}
+	json.NewEncoder(w).Encode(handler(params))
+}
+
+// calls returns the parameters of every request sent to apiPath.
+func (f *fakeServer) calls(apiPath string) []map[string]any {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	var calls []map[string]any
+	for _, request := range f.requests {
+		if request.Path == apiPath {
+			calls = append(calls, request.Params)
+		}
+	}
+	return calls
+}
+
+func resourceSchema(t *testing.T, r resource.Resource) schema.Schema {
+	t.Helper()
+	var resp resource.SchemaResponse
+	r.Schema(context.Background(), resource.SchemaRequest{}, &resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatal(resp.Diagnostics)
+	}
+	return resp.Schema
+}
+
+func newPlan(t *testing.T, s schema.Schema, model any) tfsdk.Plan {
+	t.Helper()
+	plan := tfsdk.Plan{Schema: s}
+	if diags := plan.Set(context.Background(), model); diags.HasError() {
+		t.Fatal(diags)
+	}
+	return plan
+}
+
+func newState(t *testing.T, s schema.Schema, model any) tfsdk.State {
+	t.Helper()
+	state := tfsdk.State{Schema: s}
+	if diags := state.Set(context.Background(), model); diags.HasError() {
+		t.Fatal(diags)
+	}
+	return state
+}
+
+func newConfig(t *testing.T, s schema.Schema, model any) tfsdk.Config {
+	t.Helper()
+	return tfsdk.Config{Schema: s, Raw: newState(t, s, model).Raw}
+}
+
+// getState decodes a response state into model.
+func getState(t *testing.T, state tfsdk.State, model any) {
+	t.Helper()
+	if diags := state.Get(context.Background(), model); diags.HasError() {
+		t.Fatal(diags)
+	}
+}
+
+// readDataSource runs the Read of d with config and decodes the resulting
+// state into result when it succeeds.
+func readDataSource(t *testing.T, d datasource.DataSource, config, result any) diag.Diagnostics {
+	t.Helper()
+	ctx := context.Background()
+	var schemaResp datasource.SchemaResponse
+	d.Schema(ctx, datasource.SchemaRequest{}, &schemaResp)
+	configState := tfsdk.State{Schema: schemaResp.Schema}
+	if diags := configState.Set(ctx, config); diags.HasError() {
+		t.Fatal(diags)
+	}
+	resp := datasource.ReadResponse{State: tfsdk.State{Schema: schemaResp.Schema}}
+	d.Read(ctx, datasource.ReadRequest{Config: tfsdk.Config{Schema: schemaResp.Schema, Raw: configState.Raw}}, &resp)
+	if !resp.Diagnostics.HasError() {
+		getState(t, resp.State, result)
+	}
+	return resp.Diagnostics
+}
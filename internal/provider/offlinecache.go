@@ -0,0 +1,270 @@
+package provider
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"golang.org/x/crypto/scrypt"
+)
+
+// offlineCacheKeyEnv is the environment variable holding the offline cache
+// passphrase when offline_cache_passphrase is not set.
+var offlineCacheKeyEnv = "SECURDEN_OFFLINE_CACHE_KEY"
+
+// SecurdenOfflineCache holds non-secret account metadata for plans that run
+// while the server is unreachable, nil when not configured.
+var SecurdenOfflineCache *offlineCache
+
+// offlineAccount is the metadata kept for an account. It must never contain
+// passwords, keys or any other secret field.
+type offlineAccount struct {
+	AccountID    int64     `json:"account_id"`
+	AccountName  string    `json:"account_name,omitempty"`
+	AccountTitle string    `json:"account_title,omitempty"`
+	AccountType  string    `json:"account_type,omitempty"`
+	Address      string    `json:"address,omitempty"`
+	Port         int64     `json:"port,omitempty"`
+	Tags         []string  `json:"tags,omitempty"`
+	ExpiresAt    string    `json:"expires_at,omitempty"`
+	CachedAt     time.Time `json:"cached_at"`
+}
+
+type offlineCacheFile struct {
+	Version    int    `json:"version"`
+	Salt       []byte `json:"salt"`
+	Nonce      []byte `json:"nonce"`
+	Ciphertext []byte `json:"ciphertext"`
+}
+
+// offlineCache is an AES-256-GCM encrypted file keyed with scrypt from a
+// passphrase, so it can be shared between operating systems. The key is
+// derived once per run and every save uses a fresh nonce.
+type offlineCache struct {
+	mu       sync.Mutex
+	path     string
+	salt     []byte
+	gcm      cipher.AEAD
+	accounts map[string]offlineAccount
+}
+
+func openOfflineCache(path, passphrase string) (*offlineCache, error) {
+	if passphrase == "" {
+		return nil, fmt.Errorf("an offline cache passphrase is required, set offline_cache_passphrase or %s", offlineCacheKeyEnv)
+	}
+	cache := &offlineCache{
+		path:     path,
+		accounts: make(map[string]offlineAccount),
+	}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		cache.salt = make([]byte, 16)
+		if _, err := rand.Read(cache.salt); err != nil {
+			return nil, err
+		}
+		cache.gcm, err = offlineCacheCipher(passphrase, cache.salt)
+		if err != nil {
+			return nil, err
+		}
+		return cache, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read offline cache: %v", err)
+	}
+	var file offlineCacheFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("failed to parse offline cache: %v", err)
+	}
+	cache.salt = file.Salt
+	cache.gcm, err = offlineCacheCipher(passphrase, file.Salt)
+	if err != nil {
+		return nil, err
+	}
+	plaintext, err := cache.gcm.Open(nil, file.Nonce, file.Ciphertext, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt offline cache, check the passphrase")
+	}
+	if err := json.Unmarshal(plaintext, &cache.accounts); err != nil {
+		return nil, fmt.Errorf("failed to parse offline cache: %v", err)
+	}
+	return cache, nil
+}
+
+func offlineCacheCipher(passphrase string, salt []byte) (cipher.AEAD, error) {
+	key, err := scrypt.Key([]byte(passphrase), salt, 1<<15, 8, 1, 32)
+	if err != nil {
+		return nil, fmt.Errorf("failed to derive offline cache key: %v", err)
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create offline cache cipher: %v", err)
+	}
+	return cipher.NewGCM(block)
+}
+
+// remember stores the metadata of account and writes the cache to disk.
+func (c *offlineCache) remember(account AccountModel) error {
+	if account.AccountID.IsNull() {
+		return nil
+	}
+	entry := offlineAccount{
+		AccountID:    account.AccountID.ValueInt64(),
+		AccountName:  account.AccountName.ValueString(),
+		AccountTitle: account.AccountTitle.ValueString(),
+		AccountType:  account.AccountType.ValueString(),
+		Address:      account.Address.ValueString(),
+		Port:         account.Port.ValueInt64(),
+		ExpiresAt:    account.ExpiresAt.ValueString(),
+		CachedAt:     time.Now().UTC(),
+	}
+	for _, tag := range account.Tags.Elements() {
+		if tagValue, ok := tag.(types.String); ok {
+			entry.Tags = append(entry.Tags, tagValue.ValueString())
+		}
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.accounts[strconv.FormatInt(entry.AccountID, 10)] = entry
+	return c.save()
+}
+
+// lookup finds an account by ID, or by name and title as get_account does.
+// A name or title matching several cached accounts is an error, since the
+// cache has no order to pick one by.
+func (c *offlineCache) lookup(account_id int64, account_name, account_title string) (offlineAccount, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if account_id != 0 {
+		entry, ok := c.accounts[strconv.FormatInt(account_id, 10)]
+		if !ok {
+			return offlineAccount{}, fmt.Errorf("account %d is not in the offline cache", account_id)
+		}
+		return entry, nil
+	}
+	var matches []offlineAccount
+	for _, entry := range c.accounts {
+		if account_name != "" && !strings.EqualFold(entry.AccountName, account_name) {
+			continue
+		}
+		if account_title != "" && !strings.EqualFold(entry.AccountTitle, account_title) {
+			continue
+		}
+		matches = append(matches, entry)
+	}
+	switch len(matches) {
+	case 0:
+		return offlineAccount{}, fmt.Errorf("the requested account is not in the offline cache")
+	case 1:
+		return matches[0], nil
+	}
+	ids := make([]string, 0, len(matches))
+	for _, entry := range matches {
+		ids = append(ids, strconv.FormatInt(entry.AccountID, 10))
+	}
+	sort.Strings(ids)
+	return offlineAccount{}, fmt.Errorf("accounts %s in the offline cache all match the requested name and title, set account_id to choose one", strings.Join(ids, ", "))
+}
+
+// accountsData returns the cached metadata of accountIDs in the shape of a
+// get_accounts response, reporting every ID missing from the cache.
+func (c *offlineCache) accountsData(accountIDs []int64) (map[string]map[string]string, map[string]string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	accounts := make(map[string]map[string]string)
+	failures := make(map[string]string)
+	for _, id := range accountIDs {
+		key := strconv.FormatInt(id, 10)
+		entry, ok := c.accounts[key]
+		if !ok {
+			failures[key] = "Account not available in the offline cache"
+			continue
+		}
+		accounts[key] = map[string]string{
+			"account_id":    key,
+			"account_name":  entry.AccountName,
+			"account_title": entry.AccountTitle,
+			"account_type":  entry.AccountType,
+			"address":       entry.Address,
+		}
+	}
+	return accounts, failures
+}
+
+func (c *offlineCache) save() error {
+	plaintext, err := json.Marshal(c.accounts)
+	if err != nil {
+		return err
+	}
+	nonce := make([]byte, c.gcm.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return err
+	}
+	data, err := json.Marshal(offlineCacheFile{
+		Version:    1,
+		Salt:       c.salt,
+		Nonce:      nonce,
+		Ciphertext: c.gcm.Seal(nil, nonce, plaintext, nil),
+	})
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(c.path), 0700); err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(c.path), ".securden-cache-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Chmod(0600); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), c.path)
+}
+
+// model converts the cached metadata into an AccountModel with every secret
+// attribute left null.
+func (entry offlineAccount) model() AccountModel {
+	tags := []attr.Value{}
+	for _, tag := range entry.Tags {
+		tags = append(tags, types.StringValue(tag))
+	}
+	account := AccountModel{
+		AccountID:        types.Int64Value(entry.AccountID),
+		AccountName:      types.StringValue(entry.AccountName),
+		AccountTitle:     types.StringValue(entry.AccountTitle),
+		AccountType:      types.StringValue(entry.AccountType),
+		Address:          types.StringValue(entry.Address),
+		Password:         types.StringNull(),
+		PrivateKey:       types.StringNull(),
+		Port:             types.Int64Null(),
+		Tags:             types.ListValueMust(types.StringType, tags),
+		AdditionalFields: types.MapNull(types.StringType),
+		Account:          types.MapNull(types.StringType),
+	}
+	if entry.Port != 0 {
+		account.Port = types.Int64Value(entry.Port)
+	}
+	account.ExpiresAt, account.DaysUntilExpiration = expirationAttributes(entry.ExpiresAt, time.Now().UTC())
+	return account
+}
@@ -0,0 +1,146 @@
+package provider
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestOfflineCacheRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "cache", "securden.json")
+	cache, err := openOfflineCache(path, "correct horse")
+	if err != nil {
+		t.Fatal(err)
+	}
+	account := AccountModel{
+		AccountID:    types.Int64Value(2000000001800),
+		AccountName:  types.StringValue("dbadmin"),
+		AccountTitle: types.StringValue("Orders DB"),
+		AccountType:  types.StringValue("PostgreSQL"),
+		Address:      types.StringValue("orders.db.internal"),
+		Port:         types.Int64Value(5432),
+		Password:     types.StringValue("hunter2"),
+		Tags:         types.ListValueMust(types.StringType, []attr.Value{types.StringValue("prod")}),
+	}
+	if err := cache.remember(account); err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, plaintext := range []string{"dbadmin", "orders.db.internal", "hunter2"} {
+		if strings.Contains(string(data), plaintext) {
+			t.Errorf("the cache file contains %q in the clear", plaintext)
+		}
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if mode := info.Mode().Perm(); mode != 0600 {
+		t.Errorf("cache file mode = %o, want 600", mode)
+	}
+
+	reopened, err := openOfflineCache(path, "correct horse")
+	if err != nil {
+		t.Fatal(err)
+	}
+	entry, err := reopened.lookup(2000000001800, "", "")
+	if err != nil {
+		t.Fatalf("account not found by ID after reopening the cache: %v", err)
+	}
+	if entry.AccountName != "dbadmin" || entry.Address != "orders.db.internal" || entry.Port != 5432 || len(entry.Tags) != 1 || entry.Tags[0] != "prod" {
+		t.Errorf("unexpected entry %+v", entry)
+	}
+	if _, err := reopened.lookup(0, "DBADMIN", "orders db"); err != nil {
+		t.Errorf("account not found by name and title: %v", err)
+	}
+	model := entry.model()
+	if !model.Password.IsNull() {
+		t.Error("the cached model has a password")
+	}
+
+	accounts, failures := reopened.accountsData([]int64{2000000001800, 42})
+	if accounts["2000000001800"]["account_title"] != "Orders DB" {
+		t.Errorf("accountsData = %v", accounts)
+	}
+	if _, ok := failures["42"]; !ok {
+		t.Errorf("missing ID not reported, failures = %v", failures)
+	}
+}
+
+func TestOfflineCacheWrongPassphrase(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "securden.json")
+	cache, err := openOfflineCache(path, "correct horse")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := cache.remember(AccountModel{AccountID: types.Int64Value(1), Tags: types.ListNull(types.StringType)}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := openOfflineCache(path, "battery staple"); err == nil {
+		t.Error("expected an error decrypting with the wrong passphrase")
+	}
+}
+
+func TestOfflineCacheRequiresPassphrase(t *testing.T) {
+	if _, err := openOfflineCache(filepath.Join(t.TempDir(), "securden.json"), ""); err == nil {
+		t.Error("expected an error without a passphrase")
+	}
+}
+
+func TestOfflineCacheLookupAmbiguous(t *testing.T) {
+	cache, err := openOfflineCache(filepath.Join(t.TempDir(), "securden.json"), "correct horse")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for id, name := range map[int64]string{2000000001800: "dbadmin", 2000000001801: "readonly"} {
+		if err := cache.remember(AccountModel{
+			AccountID:    types.Int64Value(id),
+			AccountName:  types.StringValue(name),
+			AccountTitle: types.StringValue("Orders DB"),
+			Tags:         types.ListNull(types.StringType),
+		}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	_, err = cache.lookup(0, "", "Orders DB")
+	if err == nil || !strings.Contains(err.Error(), "2000000001800, 2000000001801") {
+		t.Errorf("lookup by an ambiguous title returned %v", err)
+	}
+	entry, err := cache.lookup(0, "readonly", "Orders DB")
+	if err != nil || entry.AccountID != 2000000001801 {
+		t.Errorf("lookup by name and title = %d, %v", entry.AccountID, err)
+	}
+	if _, err := cache.lookup(0, "", "Billing DB"); err == nil {
+		t.Error("expected an error for an account that is not cached")
+	}
+}
+
+func TestOfflineModeWithSkippedConnectivityCheck(t *testing.T) {
+	newFakeServer(t, nil)
+	unreachable := httptest.NewServer(http.NotFoundHandler())
+	unreachable.Close()
+	SecurdenServerURL = unreachable.URL
+
+	if isOfflineMode() {
+		t.Fatal("offline mode without an offline cache")
+	}
+	cache, err := openOfflineCache(filepath.Join(t.TempDir(), "securden.json"), "correct horse")
+	if err != nil {
+		t.Fatal(err)
+	}
+	SecurdenOfflineCache = cache
+	SecurdenConnectivity = newConnectivityState(true)
+	if !isOfflineMode() {
+		t.Error("skip_connectivity_check kept the provider from switching to the offline cache")
+	}
+}
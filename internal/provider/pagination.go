@@ -0,0 +1,259 @@
+package provider
+
+import (
+	"context"
+	"sync"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+)
+
+// defaultPageSize is the number of items requested per page from listing
+// endpoints.
+var defaultPageSize = 500
+
+// defaultMaxParallelRequests bounds concurrent page fetches when the provider
+// block does not set max_parallel_requests.
+var defaultMaxParallelRequests = 4
+
+// defaultAccountsChunkSize is the number of account IDs sent per
+// get_accounts request when securden_accounts does not set chunk_size.
+var defaultAccountsChunkSize = 100
+
+// maxResultsAttribute is the max_results argument of the listing data
+// sources.
+func maxResultsAttribute(description string) schema.Int64Attribute {
+	return schema.Int64Attribute{
+		Optional:            true,
+		MarkdownDescription: description,
+		Validators: []validator.Int64{
+			int64validator.AtLeast(1),
+		},
+	}
+}
+
+type pageRequest struct {
+	Offset int
+	Limit  int
+	Cursor string
+}
+
+// pageResult is a single page returned by a listing endpoint. Total is the
+// overall number of items when the server reports it, or -1. NextCursor is set
+// by endpoints that use cursor based paging.
+type pageResult[T any] struct {
+	Items      []T
+	Total      int
+	NextCursor string
+}
+
+type pageFetcher[T any] func(ctx context.Context, page pageRequest) (pageResult[T], int, string)
+
+type pageSlot[T any] struct {
+	done    chan struct{}
+	result  pageResult[T]
+	code    int
+	message string
+}
+
+// pager iterates over every item of a paged listing. When the first page
+// reports the total count the remaining pages are fetched concurrently,
+// bounded by the provider's max_parallel_requests, and yielded in order.
+// Otherwise pages are fetched one after another by offset or cursor until a
+// short or empty page is returned.
+type pager[T any] struct {
+	fetch       pageFetcher[T]
+	pageSize    int
+	maxResults  int
+	parallelism int
+
+	started  bool
+	cancel   context.CancelFunc
+	slots    []*pageSlot[T]
+	slot     int
+	items    []T
+	index    int
+	yielded  int
+	offset   int
+	next     pageRequest
+	finished bool
+	code     int
+	message  string
+	current  T
+}
+
+// newPager creates an iterator over fetch. maxResults caps the number of items
+// returned, zero means no limit.
+func newPager[T any](fetch pageFetcher[T], maxResults int) *pager[T] {
+	parallelism := SecurdenMaxParallelRequests
+	if parallelism < 1 {
+		parallelism = defaultMaxParallelRequests
+	}
+	pageSize := defaultPageSize
+	if maxResults > 0 && maxResults < pageSize {
+		pageSize = maxResults
+	}
+	return &pager[T]{
+		fetch:       fetch,
+		pageSize:    pageSize,
+		maxResults:  maxResults,
+		parallelism: parallelism,
+		code:        200,
+		message:     "Success",
+	}
+}
+
+// Next advances to the next item, returning false when the listing is
+// exhausted, the max_results cap is reached or a page failed to load.
+func (p *pager[T]) Next(ctx context.Context) bool {
+	if p.maxResults > 0 && p.yielded >= p.maxResults {
+		p.stop()
+		return false
+	}
+	for p.index >= len(p.items) {
+		if !p.loadPage(ctx) {
+			p.stop()
+			return false
+		}
+	}
+	p.current = p.items[p.index]
+	p.index++
+	p.yielded++
+	return true
+}
+
+// Value returns the item at the current position of the iterator.
+func (p *pager[T]) Value() T {
+	return p.current
+}
+
+// Err returns the status code and message of the first failed page, or
+// 200 - Success.
+func (p *pager[T]) Err() (int, string) {
+	return p.code, p.message
+}
+
+// All drains the iterator into a slice.
+func (p *pager[T]) All(ctx context.Context) ([]T, int, string) {
+	return p.Collect(ctx, 0, nil)
+}
+
+// Collect returns the items accepted by keep, or every item when keep is nil.
+// Paging stops once limit items were accepted, zero means no limit, so a cap
+// applies to the filtered result rather than to the raw listing.
+func (p *pager[T]) Collect(ctx context.Context, limit int, keep func(T) bool) ([]T, int, string) {
+	items := []T{}
+	for (limit <= 0 || len(items) < limit) && p.Next(ctx) {
+		if keep == nil || keep(p.Value()) {
+			items = append(items, p.Value())
+		}
+	}
+	p.stop()
+	code, message := p.Err()
+	return items, code, message
+}
+
+// collect pages through fetch with a max_results cap. Without a filter the
+// cap is passed to the pager so fewer pages are requested, with one every
+// page is read until enough items match.
+func collect[T any](ctx context.Context, fetch pageFetcher[T], maxResults int, keep func(T) bool) ([]T, int, string) {
+	limit := maxResults
+	if keep != nil {
+		limit = 0
+	}
+	return newPager(fetch, limit).Collect(ctx, maxResults, keep)
+}
+
+func (p *pager[T]) loadPage(ctx context.Context) bool {
+	if !p.started {
+		p.started = true
+		first, code, message := p.fetch(ctx, pageRequest{Offset: 0, Limit: p.pageSize})
+		if code != 200 {
+			p.code, p.message = code, message
+			return false
+		}
+		p.setPage(first)
+		if first.Total >= 0 && first.NextCursor == "" && len(first.Items) > 0 {
+			// The server may cap the page size below the requested limit.
+			if len(first.Items) < p.pageSize {
+				p.pageSize = len(first.Items)
+			}
+			p.fetchRemaining(ctx, first.Total)
+			p.finished = true
+		}
+		return true
+	}
+	if p.slot < len(p.slots) {
+		slot := p.slots[p.slot]
+		p.slot++
+		<-slot.done
+		if slot.code != 200 {
+			p.code, p.message = slot.code, slot.message
+			return false
+		}
+		p.items, p.index = slot.result.Items, 0
+		return true
+	}
+	if p.finished {
+		return false
+	}
+	result, code, message := p.fetch(ctx, p.next)
+	if code != 200 {
+		p.code, p.message = code, message
+		return false
+	}
+	p.setPage(result)
+	return true
+}
+
+func (p *pager[T]) setPage(result pageResult[T]) {
+	p.items, p.index = result.Items, 0
+	p.offset += len(result.Items)
+	switch {
+	case result.NextCursor != "":
+		p.next = pageRequest{Limit: p.pageSize, Cursor: result.NextCursor}
+	case len(result.Items) < p.pageSize:
+		p.finished = true
+	default:
+		p.next = pageRequest{Offset: p.offset, Limit: p.pageSize}
+	}
+}
+
+// fetchRemaining schedules every page after the first one, stopping at the
+// max_results cap, with at most p.parallelism requests in flight.
+func (p *pager[T]) fetchRemaining(ctx context.Context, total int) {
+	if p.maxResults > 0 && total > p.maxResults {
+		total = p.maxResults
+	}
+	ctx, p.cancel = context.WithCancel(ctx)
+	semaphore := make(chan struct{}, p.parallelism)
+	var wg sync.WaitGroup
+	for offset := len(p.items); offset < total; offset += p.pageSize {
+		slot := &pageSlot[T]{done: make(chan struct{})}
+		p.slots = append(p.slots, slot)
+		wg.Add(1)
+		go func(offset int) {
+			defer wg.Done()
+			defer close(slot.done)
+			select {
+			case semaphore <- struct{}{}:
+			case <-ctx.Done():
+				slot.code, slot.message = 500, "Request cancelled"
+				return
+			}
+			defer func() { <-semaphore }()
+			slot.result, slot.code, slot.message = p.fetch(ctx, pageRequest{Offset: offset, Limit: p.pageSize})
+		}(offset)
+	}
+	go func() {
+		wg.Wait()
+		p.stop()
+	}()
+}
+
+func (p *pager[T]) stop() {
+	if p.cancel != nil {
+		p.cancel()
+	}
+}
@@ -0,0 +1,186 @@
+package provider
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// setPaging overrides the page size and parallelism for the duration of a
+// test.
+func setPaging(t *testing.T, pageSize, parallelism int) {
+	previousPageSize, previousParallelism := defaultPageSize, SecurdenMaxParallelRequests
+	defaultPageSize, SecurdenMaxParallelRequests = pageSize, parallelism
+	t.Cleanup(func() {
+		defaultPageSize, SecurdenMaxParallelRequests = previousPageSize, previousParallelism
+	})
+}
+
+// countingFetcher serves total integers by offset and reports the total,
+// recording the requested offsets and the peak number of requests in flight.
+type countingFetcher struct {
+	total    int
+	inFlight atomic.Int32
+	peak     atomic.Int32
+	mu       sync.Mutex
+	offsets  []int
+	failAt   int
+}
+
+func (f *countingFetcher) fetch(ctx context.Context, page pageRequest) (pageResult[int], int, string) {
+	current := f.inFlight.Add(1)
+	defer f.inFlight.Add(-1)
+	for {
+		peak := f.peak.Load()
+		if current <= peak || f.peak.CompareAndSwap(peak, current) {
+			break
+		}
+	}
+	f.mu.Lock()
+	f.offsets = append(f.offsets, page.Offset)
+	f.mu.Unlock()
+	time.Sleep(5 * time.Millisecond)
+	if f.failAt > 0 && page.Offset == f.failAt {
+		return pageResult[int]{}, 503, "Service Unavailable"
+	}
+	result := pageResult[int]{Total: f.total}
+	for i := page.Offset; i < page.Offset+page.Limit && i < f.total; i++ {
+		result.Items = append(result.Items, i)
+	}
+	return result, 200, "Success"
+}
+
+func TestPagerFetchesConcurrentlyInOrder(t *testing.T) {
+	setPaging(t, 10, 3)
+	fetcher := &countingFetcher{total: 95}
+	items, code, message := newPager(fetcher.fetch, 0).All(context.Background())
+	if code != 200 {
+		t.Fatalf("%d - %s", code, message)
+	}
+	if len(items) != 95 {
+		t.Fatalf("got %d items, want 95", len(items))
+	}
+	for i, item := range items {
+		if item != i {
+			t.Fatalf("item %d = %d, items are out of order", i, item)
+		}
+	}
+	if len(fetcher.offsets) != 10 {
+		t.Errorf("fetched %d pages, want 10", len(fetcher.offsets))
+	}
+	if peak := fetcher.peak.Load(); peak > 3 {
+		t.Errorf("%d requests were in flight, want at most 3", peak)
+	}
+	if peak := fetcher.peak.Load(); peak < 2 {
+		t.Errorf("pages were not fetched concurrently")
+	}
+}
+
+func TestPagerTruncatesToMaxResults(t *testing.T) {
+	setPaging(t, 10, 4)
+	fetcher := &countingFetcher{total: 95}
+	items, code, _ := newPager(fetcher.fetch, 25).All(context.Background())
+	if code != 200 {
+		t.Fatalf("code = %d", code)
+	}
+	if len(items) != 25 || items[24] != 24 {
+		t.Fatalf("got %v, want the first 25 items", items)
+	}
+	if len(fetcher.offsets) != 3 {
+		t.Errorf("fetched %d pages, want 3", len(fetcher.offsets))
+	}
+}
+
+func TestPagerShrinksPageSizeForSmallMaxResults(t *testing.T) {
+	setPaging(t, 500, 4)
+	fetcher := &countingFetcher{total: 95}
+	items, _, _ := newPager(fetcher.fetch, 5).All(context.Background())
+	if len(items) != 5 {
+		t.Fatalf("got %d items, want 5", len(items))
+	}
+	if len(fetcher.offsets) != 1 {
+		t.Errorf("fetched %d pages, want 1", len(fetcher.offsets))
+	}
+}
+
+func TestPagerReportsFailedPage(t *testing.T) {
+	setPaging(t, 10, 2)
+	fetcher := &countingFetcher{total: 95, failAt: 50}
+	items, code, message := newPager(fetcher.fetch, 0).All(context.Background())
+	if code != 503 || message != "Service Unavailable" {
+		t.Fatalf("got %d - %s, want 503 - Service Unavailable", code, message)
+	}
+	if len(items) != 50 {
+		t.Errorf("got %d items before the failed page, want 50", len(items))
+	}
+}
+
+func TestPagerFollowsCursors(t *testing.T) {
+	setPaging(t, 2, 4)
+	pages := map[string]pageResult[string]{
+		"":   {Items: []string{"a", "b"}, Total: -1, NextCursor: "c1"},
+		"c1": {Items: []string{"c", "d"}, Total: -1, NextCursor: "c2"},
+		"c2": {Items: []string{"e"}, Total: -1},
+	}
+	var cursors []string
+	fetch := func(ctx context.Context, page pageRequest) (pageResult[string], int, string) {
+		cursors = append(cursors, page.Cursor)
+		return pages[page.Cursor], 200, "Success"
+	}
+	items, code, _ := newPager(fetch, 0).All(context.Background())
+	if code != 200 {
+		t.Fatalf("code = %d", code)
+	}
+	if got := len(items); got != 5 || items[4] != "e" {
+		t.Fatalf("got %v", items)
+	}
+	if len(cursors) != 3 || cursors[1] != "c1" || cursors[2] != "c2" {
+		t.Errorf("requested cursors %v", cursors)
+	}
+}
+
+func TestPagerPagesByOffsetWithoutTotal(t *testing.T) {
+	setPaging(t, 4, 4)
+	var offsets []int
+	fetch := func(ctx context.Context, page pageRequest) (pageResult[int], int, string) {
+		offsets = append(offsets, page.Offset)
+		result := pageResult[int]{Total: -1}
+		for i := page.Offset; i < page.Offset+page.Limit && i < 10; i++ {
+			result.Items = append(result.Items, i)
+		}
+		return result, 200, "Success"
+	}
+	items, _, _ := newPager(fetch, 0).All(context.Background())
+	if len(items) != 10 {
+		t.Fatalf("got %d items, want 10", len(items))
+	}
+	if want := []int{0, 4, 8}; len(offsets) != len(want) || offsets[1] != 4 || offsets[2] != 8 {
+		t.Errorf("requested offsets %v, want %v", offsets, want)
+	}
+}
+
+func TestCollectAppliesMaxResultsAfterFilter(t *testing.T) {
+	setPaging(t, 10, 2)
+	fetcher := &countingFetcher{total: 95}
+	even := func(item int) bool { return item%2 == 0 }
+	items, code, _ := collect(context.Background(), fetcher.fetch, 20, even)
+	if code != 200 {
+		t.Fatalf("code = %d", code)
+	}
+	if len(items) != 20 {
+		t.Fatalf("got %d items, want 20", len(items))
+	}
+	for i, item := range items {
+		if item != 2*i {
+			t.Fatalf("item %d = %d, want %d", i, item, 2*i)
+		}
+	}
+
+	odd := func(item int) bool { return item%2 == 1 }
+	items, _, _ = collect(context.Background(), (&countingFetcher{total: 9}).fetch, 100, odd)
+	if got := len(items); got != 4 {
+		t.Errorf("got %d items from an exhausted listing, want 4: %v", got, items)
+	}
+}
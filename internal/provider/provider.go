@@ -2,17 +2,23 @@ package provider
 
 import (
 	"context"
+	"os"
+	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
 	"github.com/hashicorp/terraform-plugin-framework/function"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
 var _ provider.Provider = &securdenProvider{}
 var _ provider.ProviderWithFunctions = &securdenProvider{}
+var _ provider.ProviderWithEphemeralResources = &securdenProvider{}
 
 type securdenProvider struct {
 	version string
@@ -23,11 +29,20 @@ var SecurdenServerURL string
 var SecurdenOrg string
 var SecurdenCertificate string
 var PluginVersion string
+var SecurdenMaxParallelRequests int
+var SecurdenExpirationWarningDays = defaultExpirationWarningDays
 
 type securdenProviderModel struct {
-	ServerURL   types.String `tfsdk:"server_url"`
-	AuthToken   types.String `tfsdk:"authtoken"`
-	Certificate types.String `tfsdk:"certificate"`
+	ServerURL              types.String `tfsdk:"server_url"`
+	AuthToken              types.String `tfsdk:"authtoken"`
+	Certificate            types.String `tfsdk:"certificate"`
+	MaxParallelRequests    types.Int64  `tfsdk:"max_parallel_requests"`
+	CacheEnabled           types.Bool   `tfsdk:"cache_enabled"`
+	CacheTTL               types.String `tfsdk:"cache_ttl"`
+	OfflineCachePath       types.String `tfsdk:"offline_cache_path"`
+	OfflineCachePassphrase types.String `tfsdk:"offline_cache_passphrase"`
+	SkipConnectivityCheck  types.Bool   `tfsdk:"skip_connectivity_check"`
+	ExpirationWarningDays  types.Int64  `tfsdk:"expiration_warning_days"`
 }
 
 func (p *securdenProvider) Metadata(_ context.Context, _ provider.MetadataRequest, resp *provider.MetadataResponse) {
@@ -51,6 +66,38 @@ func (p *securdenProvider) Schema(_ context.Context, _ provider.SchemaRequest, r
 				Optional:            true,
 				MarkdownDescription: "Securden Server SSL Certificate",
 			},
+			"max_parallel_requests": schema.Int64Attribute{
+				Optional:            true,
+				MarkdownDescription: "Maximum number of concurrent requests used when fetching paged listings. Defaults to 4.",
+			},
+			"cache_enabled": schema.BoolAttribute{
+				Optional:            true,
+				MarkdownDescription: "Cache account lookups for the duration of the run, so that data sources reading the same account share a single request. Only successful responses are cached and every write clears the cache. Defaults to false.",
+			},
+			"cache_ttl": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "How long cached responses stay valid, as a Go duration such as `30s` or `5m`. Defaults to `5m`.",
+			},
+			"offline_cache_path": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Path of an encrypted file caching non-secret account metadata. When set, plans proceed in a degraded offline mode if the server is unreachable.",
+			},
+			"offline_cache_passphrase": schema.StringAttribute{
+				Optional:            true,
+				Sensitive:           true,
+				MarkdownDescription: "Passphrase used to encrypt the offline cache. Defaults to the `SECURDEN_OFFLINE_CACHE_KEY` environment variable.",
+			},
+			"skip_connectivity_check": schema.BoolAttribute{
+				Optional:            true,
+				MarkdownDescription: "Skip the reachability probe made before the first request to the server. The probe is still made when `offline_cache_path` is set, since it decides whether reads are served from the offline cache. Defaults to false.",
+			},
+			"expiration_warning_days": schema.Int64Attribute{
+				Optional:            true,
+				MarkdownDescription: "Warn when an account read by `securden_account` or the `securden_account` resource has expired or expires within this many days. Defaults to 0, which disables the warnings.",
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+			},
 		},
 	}
 }
@@ -59,6 +106,30 @@ func (p *securdenProvider) Configure(ctx context.Context, req provider.Configure
 	var config securdenProviderModel
 	diags := req.Config.Get(ctx, &config)
 	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if config.ServerURL.IsUnknown() || config.AuthToken.IsUnknown() || config.Certificate.IsUnknown() {
+		// The server may be created by the same configuration, defer until
+		// its URL and token are known.
+		if req.ClientCapabilities.DeferralAllowed {
+			resp.Deferred = &provider.Deferred{Reason: provider.DeferredReasonProviderConfigUnknown}
+			return
+		}
+		// Clear anything left over from an earlier configuration so no request
+		// reaches a previous server with its token.
+		SecurdenServerURL = ""
+		SecurdenAuthToken = ""
+		SecurdenCertificate = ""
+		SecurdenCache = nil
+		SecurdenOfflineCache = nil
+		SecurdenConnectivity = newConnectivityState(false)
+		resp.Diagnostics.AddError(
+			"Provider Configuration Unknown",
+			"server_url, authtoken or certificate is not known yet and this Terraform version does not support deferred actions. Apply the resources they depend on first, for example with -target.",
+		)
+		return
+	}
 	SecurdenServerURL = config.ServerURL.ValueString()
 	isValidURL := isValidURL(SecurdenServerURL)
 	if !isValidURL {
@@ -73,16 +144,57 @@ func (p *securdenProvider) Configure(ctx context.Context, req provider.Configure
 			return
 		}
 	}
-	if !isServerReachable(SecurdenServerURL) {
-		resp.Diagnostics.AddError("Server not reachable", "The provided server URL is not reachable.")
-		return
+	SecurdenOfflineCache = nil
+	if offlineCachePath := config.OfflineCachePath.ValueString(); offlineCachePath != "" {
+		passphrase := config.OfflineCachePassphrase.ValueString()
+		if passphrase == "" {
+			passphrase = os.Getenv(offlineCacheKeyEnv)
+		}
+		cache, err := openOfflineCache(offlineCachePath, passphrase)
+		if err != nil {
+			resp.Diagnostics.AddError("Invalid Offline Cache", err.Error())
+			return
+		}
+		SecurdenOfflineCache = cache
 	}
+	SecurdenConnectivity = newConnectivityState(config.SkipConnectivityCheck.ValueBool())
 	SecurdenAuthToken = config.AuthToken.ValueString()
+	SecurdenMaxParallelRequests = defaultMaxParallelRequests
+	if !config.MaxParallelRequests.IsNull() {
+		if config.MaxParallelRequests.ValueInt64() < 1 {
+			resp.Diagnostics.AddError("Invalid max_parallel_requests", "max_parallel_requests must be at least 1.")
+			return
+		}
+		SecurdenMaxParallelRequests = int(config.MaxParallelRequests.ValueInt64())
+	}
+	SecurdenCache = nil
+	if config.CacheEnabled.ValueBool() {
+		ttl := defaultCacheTTL
+		if !config.CacheTTL.IsNull() {
+			parsed, err := time.ParseDuration(config.CacheTTL.ValueString())
+			if err != nil || parsed <= 0 {
+				resp.Diagnostics.AddError("Invalid cache_ttl", "cache_ttl must be a positive duration such as 30s or 5m.")
+				return
+			}
+			ttl = parsed
+		}
+		SecurdenCache = newResponseCache(ttl)
+	}
+	SecurdenExpirationWarningDays = defaultExpirationWarningDays
+	if !config.ExpirationWarningDays.IsNull() {
+		SecurdenExpirationWarningDays = config.ExpirationWarningDays.ValueInt64()
+	}
 	PluginVersion = p.version
 }
 
 func (p *securdenProvider) Resources(_ context.Context) []func() resource.Resource {
-	return []func() resource.Resource{}
+	return []func() resource.Resource{
+		account_resource,
+		folder_resource,
+		account_share,
+		user_group_resource,
+		ssh_key_account,
+	}
 }
 
 func (p *securdenProvider) DataSources(_ context.Context) []func() datasource.DataSource {
@@ -92,11 +204,30 @@ func (p *securdenProvider) DataSources(_ context.Context) []func() datasource.Da
 		add_account,
 		edit_account,
 		delete_accounts,
+		account_search,
+		account_file,
+		account_types,
+		expiring_accounts,
+		folder,
+		folders,
+		user,
+		users,
+		user_group,
 	}
 }
 
 func (p *securdenProvider) Functions(_ context.Context) []func() function.Function {
-	return []func() function.Function{}
+	return []func() function.Function{
+		database_dsn,
+		totp,
+	}
+}
+
+func (p *securdenProvider) EphemeralResources(_ context.Context) []func() ephemeral.EphemeralResource {
+	return []func() ephemeral.EphemeralResource{
+		account_totp,
+		account_file_ephemeral,
+	}
 }
 
 func Provider(version string) func() provider.Provider {
@@ -0,0 +1,104 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/provider"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// configureProvider runs Configure with config. newFakeServer must be called
+// first so the provider globals are restored after the test.
+func configureProvider(t *testing.T, config securdenProviderModel, deferralAllowed bool) provider.ConfigureResponse {
+	t.Helper()
+	ctx := context.Background()
+	p := &securdenProvider{version: "test"}
+	var schemaResp provider.SchemaResponse
+	p.Schema(ctx, provider.SchemaRequest{}, &schemaResp)
+	state := tfsdk.State{Schema: schemaResp.Schema}
+	if diags := state.Set(ctx, &config); diags.HasError() {
+		t.Fatal(diags)
+	}
+	var resp provider.ConfigureResponse
+	p.Configure(ctx, provider.ConfigureRequest{
+		Config:             tfsdk.Config{Schema: schemaResp.Schema, Raw: state.Raw},
+		ClientCapabilities: provider.ConfigureProviderClientCapabilities{DeferralAllowed: deferralAllowed},
+	}, &resp)
+	return resp
+}
+
+func TestProviderConfigureUnknown(t *testing.T) {
+	config := securdenProviderModel{
+		ServerURL: types.StringUnknown(),
+		AuthToken: types.StringValue("token"),
+	}
+
+	t.Run("deferral allowed", func(t *testing.T) {
+		newFakeServer(t, nil)
+		serverURL := SecurdenServerURL
+		resp := configureProvider(t, config, true)
+		if resp.Diagnostics.HasError() {
+			t.Fatal(resp.Diagnostics)
+		}
+		if resp.Deferred == nil || resp.Deferred.Reason != provider.DeferredReasonProviderConfigUnknown {
+			t.Errorf("deferred = %v, want provider config unknown", resp.Deferred)
+		}
+		if SecurdenServerURL != serverURL {
+			t.Errorf("server URL changed to %q while deferred", SecurdenServerURL)
+		}
+	})
+
+	t.Run("deferral not allowed", func(t *testing.T) {
+		newFakeServer(t, nil)
+		resp := configureProvider(t, config, false)
+		if !resp.Diagnostics.HasError() {
+			t.Fatal("expected an error for an unknown server_url")
+		}
+		if SecurdenServerURL != "" || SecurdenAuthToken != "" {
+			t.Errorf("server URL %q and token %q left from the earlier configuration", SecurdenServerURL, SecurdenAuthToken)
+		}
+		if err := SecurdenConnectivity.check(); err == nil {
+			t.Error("expected requests to fail while the provider is not configured")
+		}
+	})
+}
+
+func TestProviderConfigureChecksConnectivityLazily(t *testing.T) {
+	fake := newFakeServer(t, nil)
+	server := httptest.NewServer(http.HandlerFunc(fake.serve))
+	t.Cleanup(server.Close)
+
+	resp := configureProvider(t, securdenProviderModel{
+		ServerURL: types.StringValue(server.URL),
+		AuthToken: types.StringValue("token"),
+	}, false)
+	if resp.Diagnostics.HasError() {
+		t.Fatal(resp.Diagnostics)
+	}
+	if len(fake.calls("/")) != 0 {
+		t.Fatal("server probed during Configure")
+	}
+	if err := SecurdenConnectivity.check(); err != nil {
+		t.Fatal(err)
+	}
+	SecurdenConnectivity.check()
+	if probes := len(fake.calls("/")); probes != 1 {
+		t.Errorf("server probed %d times, want once", probes)
+	}
+
+	server.Close()
+	resp = configureProvider(t, securdenProviderModel{
+		ServerURL: types.StringValue(server.URL),
+		AuthToken: types.StringValue("token"),
+	}, false)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("Configure failed for an unreachable server: %v", resp.Diagnostics)
+	}
+	if err := SecurdenConnectivity.check(); err == nil {
+		t.Error("expected an error for an unreachable server")
+	}
+}
@@ -0,0 +1,151 @@
+package provider
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"strings"
+
+	"golang.org/x/crypto/ssh"
+)
+
+// SSH key algorithms and their defaults.
+var sshKeyRSA = "rsa"
+var sshKeyECDSA = "ecdsa"
+var sshKeyEd25519 = "ed25519"
+var defaultSSHKeyAlgorithm = sshKeyEd25519
+var defaultRSABits int64 = 4096
+var defaultECDSACurve = "P256"
+
+var ecdsaCurves = map[string]elliptic.Curve{
+	"P256": elliptic.P256(),
+	"P384": elliptic.P384(),
+	"P521": elliptic.P521(),
+}
+
+// sshKeyPair is a generated key pair, the private key PEM encoded in the
+// OpenSSH format.
+type sshKeyPair struct {
+	PrivateKey string
+	PublicKey  ssh.PublicKey
+}
+
+// generateSSHKey generates a key pair, encrypting the private key when a
+// passphrase is given.
+func generateSSHKey(algorithm string, rsaBits int64, curve, passphrase, comment string) (sshKeyPair, error) {
+	var private crypto.PrivateKey
+	var public crypto.PublicKey
+	switch algorithm {
+	case sshKeyRSA:
+		key, err := rsa.GenerateKey(rand.Reader, int(rsaBits))
+		if err != nil {
+			return sshKeyPair{}, err
+		}
+		private, public = key, &key.PublicKey
+	case sshKeyECDSA:
+		ellipticCurve, ok := ecdsaCurves[curve]
+		if !ok {
+			return sshKeyPair{}, fmt.Errorf("unsupported ECDSA curve %q", curve)
+		}
+		key, err := ecdsa.GenerateKey(ellipticCurve, rand.Reader)
+		if err != nil {
+			return sshKeyPair{}, err
+		}
+		private, public = key, &key.PublicKey
+	case sshKeyEd25519:
+		publicKey, key, err := ed25519.GenerateKey(rand.Reader)
+		if err != nil {
+			return sshKeyPair{}, err
+		}
+		private, public = key, publicKey
+	default:
+		return sshKeyPair{}, fmt.Errorf("unsupported key algorithm %q", algorithm)
+	}
+	sshPublic, err := ssh.NewPublicKey(public)
+	if err != nil {
+		return sshKeyPair{}, err
+	}
+	var block *pem.Block
+	if passphrase != "" {
+		block, err = ssh.MarshalPrivateKeyWithPassphrase(private, comment, []byte(passphrase))
+	} else {
+		block, err = ssh.MarshalPrivateKey(private, comment)
+	}
+	if err != nil {
+		return sshKeyPair{}, err
+	}
+	return sshKeyPair{PrivateKey: string(pem.EncodeToMemory(block)), PublicKey: sshPublic}, nil
+}
+
+// sshPublicKeyFromPrivate returns the public half of a PEM encoded private
+// key. Encrypted OpenSSH keys carry their public key in the clear, so no
+// passphrase is needed.
+func sshPublicKeyFromPrivate(privateKey string) (ssh.PublicKey, error) {
+	raw, err := ssh.ParseRawPrivateKey([]byte(privateKey))
+	if err != nil {
+		var missing *ssh.PassphraseMissingError
+		if errors.As(err, &missing) && missing.PublicKey != nil {
+			return missing.PublicKey, nil
+		}
+		return nil, err
+	}
+	signer, err := ssh.NewSignerFromKey(raw)
+	if err != nil {
+		return nil, err
+	}
+	return signer.PublicKey(), nil
+}
+
+// sshKeyParameters are the settings a key was generated with, RSABits and
+// Curve are only set for their algorithm.
+type sshKeyParameters struct {
+	Algorithm string
+	RSABits   int64
+	Curve     string
+}
+
+// sshKeyParametersOf recovers the generation settings from a public key.
+func sshKeyParametersOf(publicKey ssh.PublicKey) (sshKeyParameters, error) {
+	switch publicKey.Type() {
+	case ssh.KeyAlgoED25519:
+		return sshKeyParameters{Algorithm: sshKeyEd25519}, nil
+	case ssh.KeyAlgoECDSA256:
+		return sshKeyParameters{Algorithm: sshKeyECDSA, Curve: "P256"}, nil
+	case ssh.KeyAlgoECDSA384:
+		return sshKeyParameters{Algorithm: sshKeyECDSA, Curve: "P384"}, nil
+	case ssh.KeyAlgoECDSA521:
+		return sshKeyParameters{Algorithm: sshKeyECDSA, Curve: "P521"}, nil
+	case ssh.KeyAlgoRSA:
+		if cryptoKey, ok := publicKey.(ssh.CryptoPublicKey); ok {
+			if rsaKey, ok := cryptoKey.CryptoPublicKey().(*rsa.PublicKey); ok {
+				return sshKeyParameters{Algorithm: sshKeyRSA, RSABits: int64(rsaKey.N.BitLen())}, nil
+			}
+		}
+	}
+	return sshKeyParameters{}, fmt.Errorf("unsupported key type %q", publicKey.Type())
+}
+
+// openSSHPublicKey formats a public key as "<type> <base64>".
+func openSSHPublicKey(key ssh.PublicKey) string {
+	return strings.TrimSpace(string(ssh.MarshalAuthorizedKey(key)))
+}
+
+// authorizedKeysLine formats a public key as an authorized_keys entry with
+// optional options and comment.
+func authorizedKeysLine(publicKey, options, comment string) string {
+	parts := []string{}
+	if options != "" {
+		parts = append(parts, options)
+	}
+	parts = append(parts, publicKey)
+	if comment != "" {
+		parts = append(parts, comment)
+	}
+	return strings.Join(parts, " ") + "\n"
+}
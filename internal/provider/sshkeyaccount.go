@@ -0,0 +1,459 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/resourcevalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"golang.org/x/crypto/ssh"
+)
+
+var _ resource.Resource = &SSHKeyAccount{}
+var _ resource.ResourceWithConfigure = &SSHKeyAccount{}
+var _ resource.ResourceWithValidateConfig = &SSHKeyAccount{}
+var _ resource.ResourceWithConfigValidators = &SSHKeyAccount{}
+var _ resource.ResourceWithModifyPlan = &SSHKeyAccount{}
+var _ resource.ResourceWithImportState = &SSHKeyAccount{}
+
+var defaultSSHKeyAccountType = "Linux Account"
+
+func ssh_key_account() resource.Resource {
+	return &SSHKeyAccount{}
+}
+
+type SSHKeyAccount struct {
+	client *http.Client
+}
+
+type SSHKeyAccountModel struct {
+	ID                         types.Int64  `tfsdk:"id"`
+	AccountTitle               types.String `tfsdk:"account_title"`
+	AccountName                types.String `tfsdk:"account_name"`
+	AccountType                types.String `tfsdk:"account_type"`
+	IPAddress                  types.String `tfsdk:"ipaddress"`
+	Notes                      types.String `tfsdk:"notes"`
+	PersonalAccount            types.Bool   `tfsdk:"personal_account"`
+	FolderID                   types.Int64  `tfsdk:"folder_id"`
+	FolderPath                 types.String `tfsdk:"folder_path"`
+	KeyAlgorithm               types.String `tfsdk:"key_algorithm"`
+	RSABits                    types.Int64  `tfsdk:"rsa_bits"`
+	ECDSACurve                 types.String `tfsdk:"ecdsa_curve"`
+	Passphrase                 types.String `tfsdk:"passphrase"`
+	KeyComment                 types.String `tfsdk:"key_comment"`
+	AuthorizedKeysOptions      types.String `tfsdk:"authorized_keys_options"`
+	PrivateKeyOpenSSH          types.String `tfsdk:"private_key_openssh"`
+	PublicKeyOpenSSH           types.String `tfsdk:"public_key_openssh"`
+	PublicKeyAuthorizedKeys    types.String `tfsdk:"public_key_authorized_keys"`
+	PublicKeyFingerprintSHA256 types.String `tfsdk:"public_key_fingerprint_sha256"`
+}
+
+func (r *SSHKeyAccount) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_ssh_key_account"
+}
+
+func (r *SSHKeyAccount) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages a Securden account holding an SSH key pair generated by the provider. The private key is stored in the account `private_key` field and the public key is exposed for distribution to `authorized_keys` files.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.Int64Attribute{
+				Computed:            true,
+				MarkdownDescription: "Unique identifier of the account in Securden.",
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+			"account_title": schema.StringAttribute{
+				MarkdownDescription: "The title associated with the account.",
+				Required:            true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"account_name": schema.StringAttribute{
+				MarkdownDescription: "The name associated with the account, usually the user the key logs in as.",
+				Optional:            true,
+			},
+			"account_type": schema.StringAttribute{
+				MarkdownDescription: "Specifies the type of the account. Defaults to `Linux Account`. Changing this forces a new account to be created.",
+				Optional:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"ipaddress": schema.StringAttribute{
+				MarkdownDescription: "The IP address or hostname of the account (if applicable).",
+				Optional:            true,
+				Validators: []validator.String{
+					hostAddress(),
+				},
+			},
+			"notes": schema.StringAttribute{
+				MarkdownDescription: "Additional notes related to the account.",
+				Optional:            true,
+			},
+			"personal_account": schema.BoolAttribute{
+				MarkdownDescription: "Indicates whether the account is personal (true/false). Changing this forces a new account to be created.",
+				Optional:            true,
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.RequiresReplace(),
+				},
+			},
+			"folder_id": schema.Int64Attribute{
+				MarkdownDescription: "The ID of the folder where the account is stored.",
+				Optional:            true,
+			},
+			"folder_path": schema.StringAttribute{
+				MarkdownDescription: "Path of the folder where the account is stored, for example `Prod/Databases/Oracle`. Resolved to a folder ID when the account is saved. Conflicts with `folder_id`.",
+				Optional:            true,
+			},
+			"key_algorithm": schema.StringAttribute{
+				MarkdownDescription: "Algorithm of the generated key: `rsa`, `ecdsa` or `ed25519`. Defaults to `ed25519`. Changing this generates a new key and forces a new account to be created.",
+				Optional:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplaceIf(sshKeyStringChanged, sshKeyChangedDescription, sshKeyChangedDescription),
+				},
+				Validators: []validator.String{
+					stringvalidator.OneOf(sshKeyRSA, sshKeyECDSA, sshKeyEd25519),
+				},
+			},
+			"rsa_bits": schema.Int64Attribute{
+				MarkdownDescription: "Size of a generated RSA key: 2048, 3072 or 4096. Defaults to 4096. Changing this forces a new account to be created.",
+				Optional:            true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplaceIf(sshKeyInt64Changed, sshKeyChangedDescription, sshKeyChangedDescription),
+				},
+				Validators: []validator.Int64{
+					int64validator.OneOf(2048, 3072, 4096),
+				},
+			},
+			"ecdsa_curve": schema.StringAttribute{
+				MarkdownDescription: "Curve of a generated ECDSA key: `P256`, `P384` or `P521`. Defaults to `P256`. Changing this forces a new account to be created.",
+				Optional:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplaceIf(sshKeyStringChanged, sshKeyChangedDescription, sshKeyChangedDescription),
+				},
+				Validators: []validator.String{
+					stringvalidator.OneOf("P256", "P384", "P521"),
+				},
+			},
+			"passphrase": schema.StringAttribute{
+				MarkdownDescription: "Passphrase encrypting the private key, stored in the account `passphrase` field. Changing this forces a new account to be created.",
+				Optional:            true,
+				Sensitive:           true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"key_comment": schema.StringAttribute{
+				MarkdownDescription: "Comment embedded in the private key and appended to `public_key_authorized_keys`. Changing this forces a new account to be created.",
+				Optional:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"authorized_keys_options": schema.StringAttribute{
+				MarkdownDescription: "Options prefixed to `public_key_authorized_keys`, for example `from=\"10.0.0.0/8\",no-port-forwarding`.",
+				Optional:            true,
+			},
+			"private_key_openssh": schema.StringAttribute{
+				MarkdownDescription: "The generated private key in the OpenSSH PEM format.",
+				Computed:            true,
+				Sensitive:           true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"public_key_openssh": schema.StringAttribute{
+				MarkdownDescription: "The public key in the OpenSSH format, such as `ssh-ed25519 AAAA...`.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"public_key_authorized_keys": schema.StringAttribute{
+				MarkdownDescription: "The public key as an `authorized_keys` line, including `authorized_keys_options` and `key_comment`.",
+				Computed:            true,
+			},
+			"public_key_fingerprint_sha256": schema.StringAttribute{
+				MarkdownDescription: "The SHA256 fingerprint of the public key, as shown by `ssh-keygen -l`.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (r *SSHKeyAccount) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	client, ok := req.ProviderData.(*http.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *http.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+	r.client = client
+}
+
+func (r *SSHKeyAccount) ConfigValidators(ctx context.Context) []resource.ConfigValidator {
+	return []resource.ConfigValidator{
+		resourcevalidator.Conflicting(path.MatchRoot("folder_id"), path.MatchRoot("folder_path")),
+	}
+}
+
+func (r *SSHKeyAccount) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config SSHKeyAccountModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() || config.KeyAlgorithm.IsUnknown() {
+		return
+	}
+	algorithm := sshKeyAlgorithm(config)
+	if !config.RSABits.IsNull() && algorithm != sshKeyRSA {
+		resp.Diagnostics.AddAttributeError(path.Root("rsa_bits"), "Invalid Attribute Combination", "rsa_bits can only be set when key_algorithm is rsa.")
+	}
+	if !config.ECDSACurve.IsNull() && algorithm != sshKeyECDSA {
+		resp.Diagnostics.AddAttributeError(path.Root("ecdsa_curve"), "Invalid Attribute Combination", "ecdsa_curve can only be set when key_algorithm is ecdsa.")
+	}
+}
+
+// ModifyPlan keeps public_key_authorized_keys known when only the
+// authorized_keys options change on an existing key.
+func (r *SSHKeyAccount) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.State.Raw.IsNull() || req.Plan.Raw.IsNull() {
+		return
+	}
+	var plan SSHKeyAccountModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if plan.PublicKeyOpenSSH.IsUnknown() || plan.AuthorizedKeysOptions.IsUnknown() || plan.KeyComment.IsUnknown() {
+		return
+	}
+	line := authorizedKeysLine(plan.PublicKeyOpenSSH.ValueString(), plan.AuthorizedKeysOptions.ValueString(), plan.KeyComment.ValueString())
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("public_key_authorized_keys"), line)...)
+}
+
+func (r *SSHKeyAccount) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan SSHKeyAccountModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	rsaBits := defaultRSABits
+	if !plan.RSABits.IsNull() {
+		rsaBits = plan.RSABits.ValueInt64()
+	}
+	curve := defaultECDSACurve
+	if !plan.ECDSACurve.IsNull() {
+		curve = plan.ECDSACurve.ValueString()
+	}
+	key, err := generateSSHKey(sshKeyAlgorithm(plan), rsaBits, curve, plan.Passphrase.ValueString(), plan.KeyComment.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to Generate SSH Key", err.Error())
+		return
+	}
+	params := sshKeyAccountParams(plan)
+	setFolderParam(ctx, params, plan.FolderPath, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	params["account_type"] = defaultSSHKeyAccountType
+	setParam(params, "account_type", plan.AccountType)
+	setParam(params, "personal_account", plan.PersonalAccount)
+	setParam(params, "passphrase", plan.Passphrase)
+	params["private_key"] = key.PrivateKey
+	added_account, code, message := add_account_function(ctx, params)
+	if code != 200 && code != 0 {
+		resp.Diagnostics.AddError(fmt.Sprintf("%d - %s", code, message), "")
+		return
+	}
+	plan.ID = added_account.ID
+	plan.PrivateKeyOpenSSH = types.StringValue(key.PrivateKey)
+	plan.PublicKeyOpenSSH = types.StringValue(openSSHPublicKey(key.PublicKey))
+	plan.PublicKeyFingerprintSHA256 = types.StringValue(ssh.FingerprintSHA256(key.PublicKey))
+	plan.PublicKeyAuthorizedKeys = types.StringValue(authorizedKeysLine(plan.PublicKeyOpenSSH.ValueString(), plan.AuthorizedKeysOptions.ValueString(), plan.KeyComment.ValueString()))
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *SSHKeyAccount) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state SSHKeyAccountModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	account, code, message := refresh_account(ctx, state.ID.ValueInt64())
+	if code == http.StatusNotFound {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if code != 200 {
+		resp.Diagnostics.AddError(fmt.Sprintf("%d - %s", code, message), "")
+		return
+	}
+	state.AccountTitle = refreshString(state.AccountTitle, account.AccountTitle)
+	state.AccountName = refreshString(state.AccountName, account.AccountName)
+	if state.PrivateKeyOpenSSH.IsNull() && !account.PrivateKey.IsNull() {
+		// Imported accounts adopt the key already stored in Securden.
+		publicKey, err := sshPublicKeyFromPrivate(account.PrivateKey.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError("Unable to Parse SSH Key", fmt.Sprintf("The private key of account %d could not be parsed: %s", state.ID.ValueInt64(), err))
+			return
+		}
+		state.PrivateKeyOpenSSH = account.PrivateKey
+		state.PublicKeyOpenSSH = types.StringValue(openSSHPublicKey(publicKey))
+		state.PublicKeyFingerprintSHA256 = types.StringValue(ssh.FingerprintSHA256(publicKey))
+		state.PublicKeyAuthorizedKeys = types.StringValue(authorizedKeysLine(state.PublicKeyOpenSSH.ValueString(), state.AuthorizedKeysOptions.ValueString(), state.KeyComment.ValueString()))
+	} else if !account.PrivateKey.IsNull() && strings.TrimSpace(account.PrivateKey.ValueString()) != strings.TrimSpace(state.PrivateKeyOpenSSH.ValueString()) {
+		resp.Diagnostics.AddWarning(
+			"SSH Key Changed Outside Terraform",
+			fmt.Sprintf("The private key of account %d no longer matches the key generated by Terraform. Taint or replace the resource to generate a new key.", state.ID.ValueInt64()),
+		)
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *SSHKeyAccount) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan, state SSHKeyAccountModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	params := sshKeyAccountParams(plan)
+	setFolderParam(ctx, params, plan.FolderPath, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	setParam(params, "account_id", state.ID)
+	params["account_type"] = defaultSSHKeyAccountType
+	setParam(params, "account_type", plan.AccountType)
+	_, code, message := edit_account_function(ctx, params)
+	if code != 200 && code != 0 {
+		resp.Diagnostics.AddError(fmt.Sprintf("%d - %s", code, message), "")
+		return
+	}
+	plan.ID = state.ID
+	plan.PrivateKeyOpenSSH = state.PrivateKeyOpenSSH
+	plan.PublicKeyOpenSSH = state.PublicKeyOpenSSH
+	plan.PublicKeyFingerprintSHA256 = state.PublicKeyFingerprintSHA256
+	plan.PublicKeyAuthorizedKeys = types.StringValue(authorizedKeysLine(state.PublicKeyOpenSSH.ValueString(), plan.AuthorizedKeysOptions.ValueString(), plan.KeyComment.ValueString()))
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *SSHKeyAccount) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state SSHKeyAccountModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	params := make(map[string]any)
+	params["account_ids"] = []types.Int64{state.ID}
+	_, code, message := delete_accounts_function(ctx, params)
+	if code != 200 && code != 0 {
+		resp.Diagnostics.AddError(fmt.Sprintf("%d - %s", code, message), "")
+	}
+}
+
+func (r *SSHKeyAccount) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	id, err := strconv.ParseInt(req.ID, 10, 64)
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid Import ID", fmt.Sprintf("Expected a numeric account ID, got: %q", req.ID))
+		return
+	}
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), id)...)
+}
+
+func sshKeyAccountParams(plan SSHKeyAccountModel) map[string]any {
+	params := make(map[string]any)
+	setParam(params, "account_title", plan.AccountTitle)
+	setParam(params, "account_name", plan.AccountName)
+	setParam(params, "ipaddress", plan.IPAddress)
+	setParam(params, "notes", plan.Notes)
+	setParam(params, "folder_id", plan.FolderID)
+	return params
+}
+
+var sshKeyChangedDescription = "Generates a new key when the key parameters no longer describe the stored key."
+
+func sshKeyStringChanged(ctx context.Context, req planmodifier.StringRequest, resp *stringplanmodifier.RequiresReplaceIfFuncResponse) {
+	resp.RequiresReplace = sshKeyChanged(ctx, req.Plan, req.State, &resp.Diagnostics)
+}
+
+func sshKeyInt64Changed(ctx context.Context, req planmodifier.Int64Request, resp *int64planmodifier.RequiresReplaceIfFuncResponse) {
+	resp.RequiresReplace = sshKeyChanged(ctx, req.Plan, req.State, &resp.Diagnostics)
+}
+
+// sshKeyChanged compares the planned key parameters, defaults applied, with
+// the parameters of the key in state. The stored public key is inspected,
+// so spelling out a default or setting the attributes after an import does
+// not generate a new key when they match the key.
+func sshKeyChanged(ctx context.Context, plan tfsdk.Plan, state tfsdk.State, diags *diag.Diagnostics) bool {
+	var planned, current SSHKeyAccountModel
+	diags.Append(plan.Get(ctx, &planned)...)
+	diags.Append(state.Get(ctx, &current)...)
+	if diags.HasError() {
+		return false
+	}
+	if planned.KeyAlgorithm.IsUnknown() || planned.RSABits.IsUnknown() || planned.ECDSACurve.IsUnknown() {
+		return true
+	}
+	stored := sshKeyParametersFromModel(current)
+	if publicKey, _, _, _, err := ssh.ParseAuthorizedKey([]byte(current.PublicKeyOpenSSH.ValueString())); err == nil {
+		if parameters, err := sshKeyParametersOf(publicKey); err == nil {
+			stored = parameters
+		}
+	}
+	return sshKeyParametersFromModel(planned) != stored
+}
+
+// sshKeyParametersFromModel returns the configured key parameters with the
+// defaults applied.
+func sshKeyParametersFromModel(model SSHKeyAccountModel) sshKeyParameters {
+	parameters := sshKeyParameters{Algorithm: sshKeyAlgorithm(model)}
+	switch parameters.Algorithm {
+	case sshKeyRSA:
+		parameters.RSABits = defaultRSABits
+		if !model.RSABits.IsNull() {
+			parameters.RSABits = model.RSABits.ValueInt64()
+		}
+	case sshKeyECDSA:
+		parameters.Curve = defaultECDSACurve
+		if !model.ECDSACurve.IsNull() {
+			parameters.Curve = model.ECDSACurve.ValueString()
+		}
+	}
+	return parameters
+}
+
+func sshKeyAlgorithm(model SSHKeyAccountModel) string {
+	if model.KeyAlgorithm.IsNull() {
+		return defaultSSHKeyAlgorithm
+	}
+	return model.KeyAlgorithm.ValueString()
+}
@@ -0,0 +1,59 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestSSHKeyAccountKeyParametersReplace(t *testing.T) {
+	ctx := context.Background()
+	s := resourceSchema(t, &SSHKeyAccount{})
+	key, err := generateSSHKey(sshKeyRSA, 3072, "", "", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// An imported account only has the key itself in state.
+	state := SSHKeyAccountModel{
+		ID:               types.Int64Value(2000000001800),
+		AccountTitle:     types.StringValue("deploy"),
+		PublicKeyOpenSSH: types.StringValue(openSSHPublicKey(key.PublicKey)),
+	}
+
+	tests := []struct {
+		name      string
+		algorithm types.String
+		rsaBits   types.Int64
+		replace   bool
+	}{
+		{"parameters unset", types.StringNull(), types.Int64Null(), false},
+		{"parameters match the key", types.StringValue(sshKeyRSA), types.Int64Value(3072), false},
+		{"size differs", types.StringValue(sshKeyRSA), types.Int64Value(4096), true},
+		{"algorithm differs", types.StringValue(sshKeyEd25519), types.Int64Null(), true},
+		{"algorithm unknown", types.StringUnknown(), types.Int64Value(3072), true},
+	}
+	for _, test := range tests {
+		plan := state
+		plan.KeyAlgorithm = test.algorithm
+		plan.RSABits = test.rsaBits
+		req := planmodifier.StringRequest{
+			Plan:       newPlan(t, s, &plan),
+			State:      newState(t, s, &state),
+			PlanValue:  plan.KeyAlgorithm,
+			StateValue: state.KeyAlgorithm,
+		}
+		var resp planmodifier.StringResponse
+		for _, modifier := range s.Attributes["key_algorithm"].(schema.StringAttribute).PlanModifiers {
+			modifier.PlanModifyString(ctx, req, &resp)
+		}
+		if resp.Diagnostics.HasError() {
+			t.Fatalf("%s: %v", test.name, resp.Diagnostics)
+		}
+		if resp.RequiresReplace != test.replace {
+			t.Errorf("%s: requires replace = %t, want %t", test.name, resp.RequiresReplace, test.replace)
+		}
+	}
+}
@@ -0,0 +1,137 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Tags modes. Authoritative tags replace every tag on the account, additive
+// tags only add and remove the tags listed in the configuration.
+var tagsModeAuthoritative = "authoritative"
+var tagsModeAdditive = "additive"
+
+// tagsValidators rejects tags the comma separated server format cannot
+// represent.
+func tagsValidators() []validator.Set {
+	return []validator.Set{
+		setvalidator.ValueStringsAre(
+			stringvalidator.LengthAtLeast(1),
+			stringvalidator.RegexMatches(regexp.MustCompile(`^[^,]*$`), "tags must not contain commas"),
+		),
+	}
+}
+
+func tagsModeValidators() []validator.String {
+	return []validator.String{
+		stringvalidator.OneOf(tagsModeAuthoritative, tagsModeAdditive),
+	}
+}
+
+func isAdditiveTags(mode types.String) bool {
+	return mode.ValueString() == tagsModeAdditive
+}
+
+// tagsFromSet returns the tags of a set attribute in sorted order, nil when
+// the attribute is not set and an empty slice for an empty set.
+func tagsFromSet(ctx context.Context, set types.Set, diags *diag.Diagnostics) []string {
+	if set.IsNull() || set.IsUnknown() {
+		return nil
+	}
+	tags := []string{}
+	diags.Append(set.ElementsAs(ctx, &tags, false)...)
+	if tags == nil {
+		tags = []string{}
+	}
+	sort.Strings(tags)
+	return tags
+}
+
+func tagsToSet(tags []string) types.Set {
+	sorted := append([]string{}, tags...)
+	sort.Strings(sorted)
+	elements := make([]attr.Value, 0, len(sorted))
+	for _, tag := range sorted {
+		elements = append(elements, types.StringValue(tag))
+	}
+	return types.SetValueMust(types.StringType, elements)
+}
+
+// tagsFromList converts the tags read by get_account.
+func tagsFromList(ctx context.Context, list types.List, diags *diag.Diagnostics) []string {
+	if list.IsNull() || list.IsUnknown() {
+		return []string{}
+	}
+	var tags []string
+	diags.Append(list.ElementsAs(ctx, &tags, false)...)
+	return tags
+}
+
+// accountTags reads the tags currently set on an account.
+func accountTags(ctx context.Context, accountID int64, diags *diag.Diagnostics) []string {
+	account, code, message := refresh_account(ctx, accountID)
+	if code != 200 {
+		diags.AddError(fmt.Sprintf("%d - %s", code, message), "")
+		return nil
+	}
+	return tagsFromList(ctx, account.Tags, diags)
+}
+
+// setTagsParam serializes tags to the comma separated string the add and
+// edit account APIs expect. An empty set clears the tags.
+func setTagsParam(params map[string]any, tags []string) {
+	params["tags"] = strings.Join(tags, ",")
+}
+
+// mergeTags applies an additive tags change: tags added to the configuration
+// are added to remote, tags removed from it are removed from remote, and any
+// other remote tag is kept.
+func mergeTags(remote, previous, planned []string) []string {
+	removed := make(map[string]bool)
+	for _, tag := range previous {
+		removed[tag] = true
+	}
+	for _, tag := range planned {
+		delete(removed, tag)
+	}
+	merged := make(map[string]bool)
+	for _, tag := range remote {
+		if !removed[tag] {
+			merged[tag] = true
+		}
+	}
+	for _, tag := range planned {
+		merged[tag] = true
+	}
+	tags := make([]string, 0, len(merged))
+	for tag := range merged {
+		tags = append(tags, tag)
+	}
+	sort.Strings(tags)
+	return tags
+}
+
+// intersectTags keeps the managed tags that are still present remotely, so
+// additive mode reports drift only for the tags it manages.
+func intersectTags(managed, remote []string) []string {
+	present := make(map[string]bool, len(remote))
+	for _, tag := range remote {
+		present[tag] = true
+	}
+	tags := []string{}
+	for _, tag := range managed {
+		if present[tag] {
+			tags = append(tags, tag)
+		}
+	}
+	return tags
+}
@@ -0,0 +1,105 @@
+package provider
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestMergeTags(t *testing.T) {
+	tests := []struct {
+		name     string
+		remote   []string
+		previous []string
+		planned  []string
+		want     []string
+	}{
+		{
+			name:    "adds planned tags and keeps unmanaged ones",
+			remote:  []string{"team-a", "prod"},
+			planned: []string{"pci"},
+			want:    []string{"pci", "prod", "team-a"},
+		},
+		{
+			name:     "removes tags dropped from the configuration",
+			remote:   []string{"team-a", "prod", "pci"},
+			previous: []string{"pci", "prod"},
+			planned:  []string{"prod"},
+			want:     []string{"prod", "team-a"},
+		},
+		{
+			name:     "keeps a removed tag that is planned again",
+			remote:   []string{"prod"},
+			previous: []string{"prod"},
+			planned:  []string{"prod"},
+			want:     []string{"prod"},
+		},
+		{
+			name:     "empty plan removes every managed tag",
+			remote:   []string{"team-a", "prod"},
+			previous: []string{"prod"},
+			planned:  []string{},
+			want:     []string{"team-a"},
+		},
+		{
+			name: "nothing to merge",
+			want: []string{},
+		},
+	}
+	for _, test := range tests {
+		if got := mergeTags(test.remote, test.previous, test.planned); !reflect.DeepEqual(got, test.want) {
+			t.Errorf("%s: got %v, want %v", test.name, got, test.want)
+		}
+	}
+}
+
+func TestIntersectTags(t *testing.T) {
+	tests := []struct {
+		managed []string
+		remote  []string
+		want    []string
+	}{
+		{[]string{"prod", "pci"}, []string{"team-a", "prod"}, []string{"prod"}},
+		{[]string{"prod"}, []string{}, []string{}},
+		{nil, []string{"prod"}, []string{}},
+	}
+	for _, test := range tests {
+		if got := intersectTags(test.managed, test.remote); !reflect.DeepEqual(got, test.want) {
+			t.Errorf("intersectTags(%v, %v) = %v, want %v", test.managed, test.remote, got, test.want)
+		}
+	}
+}
+
+func TestTagsFromSet(t *testing.T) {
+	ctx := context.Background()
+	var diags diag.Diagnostics
+	if tags := tagsFromSet(ctx, types.SetNull(types.StringType), &diags); tags != nil {
+		t.Errorf("null set = %v, want nil", tags)
+	}
+	if tags := tagsFromSet(ctx, types.SetValueMust(types.StringType, []attr.Value{}), &diags); tags == nil || len(tags) != 0 {
+		t.Errorf("empty set = %#v, want an empty slice", tags)
+	}
+	set := types.SetValueMust(types.StringType, []attr.Value{types.StringValue("prod"), types.StringValue("pci")})
+	if tags := tagsFromSet(ctx, set, &diags); !reflect.DeepEqual(tags, []string{"pci", "prod"}) {
+		t.Errorf("tags = %v, want sorted tags", tags)
+	}
+	if diags.HasError() {
+		t.Fatal(diags)
+	}
+}
+
+func TestSetTagsParam(t *testing.T) {
+	params := map[string]any{}
+	setTagsParam(params, []string{"pci", "prod"})
+	if params["tags"] != "pci,prod" {
+		t.Errorf("tags = %q", params["tags"])
+	}
+	setTagsParam(params, []string{})
+	if params["tags"] != "" {
+		t.Errorf("tags = %q, want an empty string to clear the tags", params["tags"])
+	}
+}
@@ -0,0 +1,176 @@
+package provider
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/base32"
+	"encoding/binary"
+	"fmt"
+	"hash"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+var _ function.Function = &TOTP{}
+
+func totp() function.Function {
+	return &TOTP{}
+}
+
+type TOTP struct{}
+
+type totpConfig struct {
+	Secret    []byte
+	Digits    int
+	Period    int64
+	Algorithm string
+}
+
+func (f *TOTP) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "totp"
+}
+
+func (f *TOTP) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Generates a time-based one-time password (RFC 6238).",
+		MarkdownDescription: "Generates a time-based one-time password (RFC 6238) for the given secret at the given time. " +
+			"The secret can be a base32 encoded seed, using 6 digits, a 30 second period and SHA1, or an `otpauth://totp/` URI " +
+			"whose `digits`, `period` and `algorithm` parameters override those defaults.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "secret",
+				MarkdownDescription: "Base32 encoded TOTP seed or `otpauth://totp/` URI.",
+			},
+			function.StringParameter{
+				Name:                "time",
+				MarkdownDescription: "RFC 3339 timestamp to generate the code for, for example `timestamp()`.",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *TOTP) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var secret string
+	var timestamp string
+	resp.Error = function.ConcatFuncErrors(resp.Error, req.Arguments.Get(ctx, &secret, &timestamp))
+	if resp.Error != nil {
+		return
+	}
+	config, err := parseTOTPSecret(secret)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, err.Error())
+		return
+	}
+	at, err := time.Parse(time.RFC3339, timestamp)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(1, fmt.Sprintf("Invalid RFC 3339 timestamp %q.", timestamp))
+		return
+	}
+	code, err := generateTOTP(config, at)
+	if err != nil {
+		resp.Error = function.NewFuncError(err.Error())
+		return
+	}
+	resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, code))
+}
+
+// parseTOTPSecret accepts either a bare base32 seed or an otpauth:// URI as
+// exported by most authenticator apps.
+func parseTOTPSecret(secret string) (totpConfig, error) {
+	config := totpConfig{Digits: 6, Period: 30, Algorithm: "SHA1"}
+	secret = strings.TrimSpace(secret)
+	if strings.HasPrefix(strings.ToLower(secret), "otpauth://") {
+		parsedURL, err := url.Parse(secret)
+		if err != nil {
+			return config, fmt.Errorf("invalid otpauth URI: %v", err)
+		}
+		if !strings.EqualFold(parsedURL.Host, "totp") {
+			return config, fmt.Errorf("unsupported otpauth type %q, only totp is supported", parsedURL.Host)
+		}
+		q := parsedURL.Query()
+		secret = q.Get("secret")
+		if digits := q.Get("digits"); digits != "" {
+			value, err := strconv.Atoi(digits)
+			if err != nil {
+				return config, fmt.Errorf("invalid digits %q in otpauth URI", digits)
+			}
+			config.Digits = value
+		}
+		if period := q.Get("period"); period != "" {
+			value, err := strconv.ParseInt(period, 10, 64)
+			if err != nil {
+				return config, fmt.Errorf("invalid period %q in otpauth URI", period)
+			}
+			config.Period = value
+		}
+		if algorithm := q.Get("algorithm"); algorithm != "" {
+			config.Algorithm = strings.ToUpper(algorithm)
+		}
+	}
+	key, err := decodeTOTPSeed(secret)
+	if err != nil {
+		return config, err
+	}
+	config.Secret = key
+	return config, nil
+}
+
+func decodeTOTPSeed(seed string) ([]byte, error) {
+	seed = strings.ToUpper(strings.NewReplacer(" ", "", "-", "", "=", "").Replace(seed))
+	if seed == "" {
+		return nil, fmt.Errorf("the TOTP secret is empty")
+	}
+	key, err := base32.StdEncoding.WithPadding(base32.NoPadding).DecodeString(seed)
+	if err != nil {
+		return nil, fmt.Errorf("the TOTP secret is not valid base32")
+	}
+	return key, nil
+}
+
+func generateTOTP(config totpConfig, at time.Time) (string, error) {
+	var hasher func() hash.Hash
+	switch strings.ToUpper(config.Algorithm) {
+	case "SHA1":
+		hasher = sha1.New
+	case "SHA256":
+		hasher = sha256.New
+	case "SHA512":
+		hasher = sha512.New
+	default:
+		return "", fmt.Errorf("unsupported TOTP algorithm %q, expected SHA1, SHA256 or SHA512", config.Algorithm)
+	}
+	if config.Digits < 6 || config.Digits > 10 {
+		return "", fmt.Errorf("unsupported TOTP digits %d, expected a value between 6 and 10", config.Digits)
+	}
+	if config.Period <= 0 {
+		return "", fmt.Errorf("the TOTP period must be greater than zero")
+	}
+
+	counter := make([]byte, 8)
+	binary.BigEndian.PutUint64(counter, uint64(at.Unix()/config.Period))
+	mac := hmac.New(hasher, config.Secret)
+	mac.Write(counter)
+	sum := mac.Sum(nil)
+
+	// Dynamic truncation as described in RFC 4226 section 5.3.
+	offset := sum[len(sum)-1] & 0x0f
+	value := uint64(binary.BigEndian.Uint32(sum[offset:offset+4]) & 0x7fffffff)
+	modulo := uint64(1)
+	for i := 0; i < config.Digits; i++ {
+		modulo *= 10
+	}
+	return fmt.Sprintf("%0*d", config.Digits, value%modulo), nil
+}
+
+func totpExpiry(config totpConfig, at time.Time) time.Time {
+	step := at.Unix() / config.Period
+	return time.Unix((step+1)*config.Period, 0).UTC()
+}
@@ -0,0 +1,122 @@
+package provider
+
+import (
+	"encoding/base32"
+	"testing"
+	"time"
+)
+
+// RFC 6238 appendix B test vectors.
+func TestGenerateTOTP(t *testing.T) {
+	secrets := map[string][]byte{
+		"SHA1":   []byte("12345678901234567890"),
+		"SHA256": []byte("12345678901234567890123456789012"),
+		"SHA512": []byte("1234567890123456789012345678901234567890123456789012345678901234"),
+	}
+	tests := []struct {
+		unix      int64
+		algorithm string
+		want      string
+	}{
+		{59, "SHA1", "94287082"},
+		{59, "SHA256", "46119246"},
+		{59, "SHA512", "90693936"},
+		{1111111109, "SHA1", "07081804"},
+		{1111111109, "SHA256", "68084774"},
+		{1111111109, "SHA512", "25091201"},
+		{1111111111, "SHA1", "14050471"},
+		{1111111111, "SHA256", "67062674"},
+		{1111111111, "SHA512", "99943326"},
+		{1234567890, "SHA1", "89005924"},
+		{1234567890, "SHA256", "91819424"},
+		{1234567890, "SHA512", "93441116"},
+		{2000000000, "SHA1", "69279037"},
+		{2000000000, "SHA256", "90698825"},
+		{2000000000, "SHA512", "38618901"},
+		{20000000000, "SHA1", "65353130"},
+		{20000000000, "SHA256", "77737706"},
+		{20000000000, "SHA512", "47863826"},
+	}
+	for _, test := range tests {
+		config := totpConfig{Secret: secrets[test.algorithm], Digits: 8, Period: 30, Algorithm: test.algorithm}
+		got, err := generateTOTP(config, time.Unix(test.unix, 0))
+		if err != nil {
+			t.Fatalf("generateTOTP(%s, %d): %v", test.algorithm, test.unix, err)
+		}
+		if got != test.want {
+			t.Errorf("generateTOTP(%s, %d) = %s, want %s", test.algorithm, test.unix, got, test.want)
+		}
+	}
+}
+
+func TestGenerateTOTPInvalidConfig(t *testing.T) {
+	tests := map[string]totpConfig{
+		"algorithm": {Secret: []byte("secret"), Digits: 6, Period: 30, Algorithm: "MD5"},
+		"digits":    {Secret: []byte("secret"), Digits: 4, Period: 30, Algorithm: "SHA1"},
+		"period":    {Secret: []byte("secret"), Digits: 6, Period: 0, Algorithm: "SHA1"},
+	}
+	for name, config := range tests {
+		if _, err := generateTOTP(config, time.Unix(59, 0)); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
+
+func TestParseTOTPSecret(t *testing.T) {
+	seed := base32.StdEncoding.EncodeToString([]byte("12345678901234567890"))
+	tests := []struct {
+		name   string
+		secret string
+		want   totpConfig
+	}{
+		{
+			name:   "bare seed",
+			secret: seed,
+			want:   totpConfig{Digits: 6, Period: 30, Algorithm: "SHA1"},
+		},
+		{
+			name:   "lower case seed with spaces",
+			secret: " gezd gnbv gy3t qojq gezd gnbv gy3t qojq ",
+			want:   totpConfig{Digits: 6, Period: 30, Algorithm: "SHA1"},
+		},
+		{
+			name:   "otpauth URI",
+			secret: "otpauth://totp/Securden:admin?secret=" + seed + "&digits=8&period=60&algorithm=sha256",
+			want:   totpConfig{Digits: 8, Period: 60, Algorithm: "SHA256"},
+		},
+	}
+	for _, test := range tests {
+		got, err := parseTOTPSecret(test.secret)
+		if err != nil {
+			t.Fatalf("%s: %v", test.name, err)
+		}
+		if string(got.Secret) != "12345678901234567890" {
+			t.Errorf("%s: secret = %q", test.name, got.Secret)
+		}
+		if got.Digits != test.want.Digits || got.Period != test.want.Period || got.Algorithm != test.want.Algorithm {
+			t.Errorf("%s: got digits=%d period=%d algorithm=%s, want digits=%d period=%d algorithm=%s",
+				test.name, got.Digits, got.Period, got.Algorithm, test.want.Digits, test.want.Period, test.want.Algorithm)
+		}
+	}
+}
+
+func TestParseTOTPSecretInvalid(t *testing.T) {
+	for _, secret := range []string{
+		"",
+		"not base32!",
+		"otpauth://hotp/Securden:admin?secret=GEZDGNBVGY3TQOJQ",
+		"otpauth://totp/Securden:admin?secret=GEZDGNBVGY3TQOJQ&digits=eight",
+	} {
+		if _, err := parseTOTPSecret(secret); err == nil {
+			t.Errorf("parseTOTPSecret(%q): expected an error", secret)
+		}
+	}
+}
+
+func TestTOTPExpiry(t *testing.T) {
+	config := totpConfig{Period: 30}
+	got := totpExpiry(config, time.Unix(59, 0))
+	if want := time.Unix(60, 0).UTC(); !got.Equal(want) {
+		t.Errorf("totpExpiry = %s, want %s", got, want)
+	}
+}
@@ -0,0 +1,123 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/datasourcevalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ datasource.DataSource = &User{}
+var _ datasource.DataSourceWithConfigValidators = &User{}
+
+func user() datasource.DataSource {
+	return &User{}
+}
+
+type User struct {
+	client *http.Client
+}
+
+type UserModel struct {
+	UserID    types.Int64  `tfsdk:"user_id"`
+	Username  types.String `tfsdk:"username"`
+	Email     types.String `tfsdk:"email"`
+	FirstName types.String `tfsdk:"first_name"`
+	LastName  types.String `tfsdk:"last_name"`
+	Status    types.String `tfsdk:"status"`
+}
+
+func (d *User) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_user"
+}
+
+func (d *User) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Retrieves a Securden user by username or email address.",
+
+		Attributes: map[string]schema.Attribute{
+			"username": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "Login name of the user. Exactly one of `username` and `email` must be set.",
+			},
+			"email": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "Email address of the user. Exactly one of `username` and `email` must be set.",
+			},
+			"user_id": schema.Int64Attribute{
+				Computed:            true,
+				MarkdownDescription: "Unique identifier of the user.",
+			},
+			"first_name": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "First name of the user.",
+			},
+			"last_name": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Last name of the user.",
+			},
+			"status": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Status of the user account, for example `Active` or `Disabled`.",
+			},
+		},
+	}
+}
+
+func (d *User) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	client, ok := req.ProviderData.(*http.Client)
+	if !ok {
+		resp.Diagnostics.AddWarning(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *http.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+	d.client = client
+}
+
+func (d *User) ConfigValidators(ctx context.Context) []datasource.ConfigValidator {
+	return []datasource.ConfigValidator{
+		datasourcevalidator.ExactlyOneOf(path.MatchRoot("username"), path.MatchRoot("email")),
+	}
+}
+
+func (d *User) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data UserModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	entries, code, message := get_users(ctx)
+	if code != 200 {
+		resp.Diagnostics.AddError(fmt.Sprintf("%d - %s", code, message), "")
+		return
+	}
+	field, value := "username", data.Username.ValueString()
+	if !data.Email.IsNull() {
+		field, value = "email", data.Email.ValueString()
+	}
+	entry, ok := findUser(entries, field, value)
+	if !ok {
+		resp.Diagnostics.AddError("404 - User not found", fmt.Sprintf("No user exists with %s %q.", field, value))
+		return
+	}
+	summary := entry.summary()
+	data.UserID = summary.UserID
+	data.Username = summary.Username
+	data.Email = summary.Email
+	data.FirstName = summary.FirstName
+	data.LastName = summary.LastName
+	data.Status = summary.Status
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -0,0 +1,142 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/datasourcevalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ datasource.DataSource = &UserGroup{}
+var _ datasource.DataSourceWithConfigValidators = &UserGroup{}
+
+// localGroupSource is the source Securden reports for groups created in
+// Securden itself rather than imported from a directory.
+var localGroupSource = "local"
+
+func user_group() datasource.DataSource {
+	return &UserGroup{}
+}
+
+type UserGroup struct {
+	client *http.Client
+}
+
+type UserGroupModel struct {
+	GroupID     types.Int64   `tfsdk:"group_id"`
+	Name        types.String  `tfsdk:"name"`
+	Description types.String  `tfsdk:"description"`
+	Source      types.String  `tfsdk:"source"`
+	MemberIDs   []types.Int64 `tfsdk:"member_ids"`
+}
+
+// userGroupEntry is a user group as returned by get_user_groups.
+type userGroupEntry struct {
+	ID          int64
+	Name        string
+	Description string
+	Source      string
+	MemberIDs   []int64
+}
+
+func (d *UserGroup) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_user_group"
+}
+
+func (d *UserGroup) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Retrieves a Securden user group by name or ID.",
+
+		Attributes: map[string]schema.Attribute{
+			"group_id": schema.Int64Attribute{
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "Unique identifier of the group. Exactly one of `group_id` and `name` must be set.",
+			},
+			"name": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "Name of the group. Exactly one of `group_id` and `name` must be set.",
+			},
+			"description": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Description of the group.",
+			},
+			"source": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Where the group comes from, `local` for groups created in Securden.",
+			},
+			"member_ids": schema.ListAttribute{
+				ElementType:         types.Int64Type,
+				Computed:            true,
+				MarkdownDescription: "IDs of the users in the group.",
+			},
+		},
+	}
+}
+
+func (d *UserGroup) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	client, ok := req.ProviderData.(*http.Client)
+	if !ok {
+		resp.Diagnostics.AddWarning(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *http.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+	d.client = client
+}
+
+func (d *UserGroup) ConfigValidators(ctx context.Context) []datasource.ConfigValidator {
+	return []datasource.ConfigValidator{
+		datasourcevalidator.ExactlyOneOf(path.MatchRoot("group_id"), path.MatchRoot("name")),
+	}
+}
+
+func (d *UserGroup) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data UserGroupModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	entries, code, message := get_user_groups(ctx)
+	if code != 200 {
+		resp.Diagnostics.AddError(fmt.Sprintf("%d - %s", code, message), "")
+		return
+	}
+	var entry userGroupEntry
+	found := false
+	for _, candidate := range entries {
+		if (!data.GroupID.IsNull() && candidate.ID == data.GroupID.ValueInt64()) ||
+			(!data.Name.IsNull() && strings.EqualFold(candidate.Name, data.Name.ValueString())) {
+			entry, found = candidate, true
+			break
+		}
+	}
+	if !found {
+		lookup := fmt.Sprintf("name %q", data.Name.ValueString())
+		if !data.GroupID.IsNull() {
+			lookup = fmt.Sprintf("ID %d", data.GroupID.ValueInt64())
+		}
+		resp.Diagnostics.AddError("404 - User group not found", fmt.Sprintf("No user group exists with %s.", lookup))
+		return
+	}
+	data.GroupID = types.Int64Value(entry.ID)
+	data.Name = types.StringValue(entry.Name)
+	data.Description = types.StringValue(entry.Description)
+	data.Source = types.StringValue(entry.Source)
+	data.MemberIDs = []types.Int64{}
+	for _, memberID := range entry.MemberIDs {
+		data.MemberIDs = append(data.MemberIDs, types.Int64Value(memberID))
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -0,0 +1,276 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ resource.Resource = &UserGroupResource{}
+var _ resource.ResourceWithConfigure = &UserGroupResource{}
+var _ resource.ResourceWithImportState = &UserGroupResource{}
+
+func user_group_resource() resource.Resource {
+	return &UserGroupResource{}
+}
+
+type UserGroupResource struct {
+	client *http.Client
+}
+
+type UserGroupResourceModel struct {
+	ID          types.Int64  `tfsdk:"id"`
+	Name        types.String `tfsdk:"name"`
+	Description types.String `tfsdk:"description"`
+	MemberIDs   types.Set    `tfsdk:"member_ids"`
+}
+
+func (r *UserGroupResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_user_group"
+}
+
+func (r *UserGroupResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages a local Securden user group and its membership. Groups imported from a directory cannot be managed.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.Int64Attribute{
+				Computed:            true,
+				MarkdownDescription: "Unique identifier of the group in Securden.",
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+			"name": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Name of the group.",
+			},
+			"description": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Description of the group.",
+			},
+			"member_ids": schema.SetAttribute{
+				ElementType:         types.Int64Type,
+				Optional:            true,
+				MarkdownDescription: "IDs of the users in the group. The list is authoritative, members that are not listed are removed. When `member_ids` is not set, the membership is left to be managed outside Terraform.",
+			},
+		},
+	}
+}
+
+func (r *UserGroupResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	client, ok := req.ProviderData.(*http.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *http.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+	r.client = client
+}
+
+func (r *UserGroupResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan UserGroupResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	params := make(map[string]any)
+	setParam(params, "group_name", plan.Name)
+	setParam(params, "description", plan.Description)
+	id, code, message := write_request(ctx, params, "/api/add_user_group", POST)
+	if code != 200 {
+		resp.Diagnostics.AddError(fmt.Sprintf("%d - %s", code, message), "")
+		return
+	}
+	plan.ID = types.Int64Value(id)
+	// Save the group before the membership so a failure below does not
+	// orphan it.
+	resp.Diagnostics.Append(resp.State.Set(ctx, &UserGroupResourceModel{
+		ID:          plan.ID,
+		Name:        plan.Name,
+		Description: plan.Description,
+		MemberIDs:   types.SetNull(types.Int64Type),
+	})...)
+	planned := int64sFromSet(ctx, plan.MemberIDs, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if !updateGroupMembers(ctx, plan.ID, nil, planned, &resp.Diagnostics) {
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *UserGroupResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state UserGroupResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	entries, code, message := get_user_groups(ctx)
+	if code != 200 {
+		resp.Diagnostics.AddError(fmt.Sprintf("%d - %s", code, message), "")
+		return
+	}
+	for _, entry := range entries {
+		if entry.ID != state.ID.ValueInt64() {
+			continue
+		}
+		if entry.Source != "" && !strings.EqualFold(entry.Source, localGroupSource) {
+			resp.Diagnostics.AddError(
+				"Unsupported User Group",
+				fmt.Sprintf("The user group %q is synchronized from %s and cannot be managed by Terraform.", entry.Name, entry.Source),
+			)
+			return
+		}
+		state.Name = types.StringValue(entry.Name)
+		state.Description = refreshString(state.Description, types.StringValue(entry.Description))
+		// Membership is only tracked when member_ids is configured, so groups
+		// whose members are managed elsewhere do not show drift.
+		if !state.MemberIDs.IsNull() {
+			state.MemberIDs = int64sToSet(entry.MemberIDs, &resp.Diagnostics)
+		}
+		resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+		return
+	}
+	resp.State.RemoveResource(ctx)
+}
+
+func (r *UserGroupResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan, state UserGroupResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if !plan.Name.Equal(state.Name) || !plan.Description.Equal(state.Description) {
+		params := make(map[string]any)
+		setParam(params, "group_id", state.ID)
+		setParam(params, "group_name", plan.Name)
+		params["description"] = plan.Description.ValueString()
+		_, code, message := write_request(ctx, params, "/api/edit_user_group", PUT)
+		if code != 200 {
+			resp.Diagnostics.AddError(fmt.Sprintf("%d - %s", code, message), "")
+			return
+		}
+	}
+	if !plan.MemberIDs.IsNull() {
+		planned := int64sFromSet(ctx, plan.MemberIDs, &resp.Diagnostics)
+		current := int64sFromSet(ctx, state.MemberIDs, &resp.Diagnostics)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		if !updateGroupMembers(ctx, state.ID, current, planned, &resp.Diagnostics) {
+			return
+		}
+	}
+	plan.ID = state.ID
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *UserGroupResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state UserGroupResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	params := make(map[string]any)
+	setParam(params, "group_id", state.ID)
+	_, code, message := write_request(ctx, params, "/api/delete_user_group", DELETE)
+	if code != 200 && code != http.StatusNotFound {
+		resp.Diagnostics.AddError(fmt.Sprintf("%d - %s", code, message), "")
+	}
+}
+
+func (r *UserGroupResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	id, err := strconv.ParseInt(req.ID, 10, 64)
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid Import ID", fmt.Sprintf("Expected a numeric user group ID, got: %q", req.ID))
+		return
+	}
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), id)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("member_ids"), types.SetValueMust(types.Int64Type, []attr.Value{}))...)
+}
+
+// updateGroupMembers adds and removes users so the group goes from current
+// to planned members.
+func updateGroupMembers(ctx context.Context, groupID types.Int64, current, planned []int64, diags *diag.Diagnostics) bool {
+	existing := make(map[int64]bool, len(current))
+	for _, id := range current {
+		existing[id] = true
+	}
+	wanted := make(map[int64]bool, len(planned))
+	added := []int64{}
+	for _, id := range planned {
+		wanted[id] = true
+		if !existing[id] {
+			added = append(added, id)
+		}
+	}
+	removed := []int64{}
+	for _, id := range current {
+		if !wanted[id] {
+			removed = append(removed, id)
+		}
+	}
+	for _, change := range []struct {
+		ids    []int64
+		apiURL string
+		method string
+	}{
+		{removed, "/api/remove_user_group_members", DELETE},
+		{added, "/api/add_user_group_members", POST},
+	} {
+		if len(change.ids) == 0 {
+			continue
+		}
+		params := make(map[string]any)
+		setParam(params, "group_id", groupID)
+		params["user_ids"] = change.ids
+		_, code, message := write_request(ctx, params, change.apiURL, change.method)
+		if code != 200 {
+			diags.AddError(fmt.Sprintf("%d - %s", code, message), "")
+			return false
+		}
+	}
+	return true
+}
+
+func int64sFromSet(ctx context.Context, set types.Set, diags *diag.Diagnostics) []int64 {
+	var values []types.Int64
+	diags.Append(set.ElementsAs(ctx, &values, false)...)
+	ids := make([]int64, 0, len(values))
+	for _, value := range values {
+		ids = append(ids, value.ValueInt64())
+	}
+	return ids
+}
+
+func int64sToSet(ids []int64, diags *diag.Diagnostics) types.Set {
+	sorted := append([]int64{}, ids...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+	elements := make([]attr.Value, 0, len(sorted))
+	for _, id := range sorted {
+		elements = append(elements, types.Int64Value(id))
+	}
+	set, setDiags := types.SetValue(types.Int64Type, elements)
+	diags.Append(setDiags...)
+	return set
+}
@@ -0,0 +1,116 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func userGroupServer(t *testing.T, members ...int64) *fakeServer {
+	ok := func(params map[string]any) any {
+		return map[string]any{"status_code": 200, "message": "Success"}
+	}
+	return newFakeServer(t, map[string]fakeHandler{
+		"/secretsmanagement/get_user_groups": func(params map[string]any) any {
+			return map[string]any{"status_code": 200, "user_groups": []map[string]any{
+				{"group_id": 120, "group_name": "DBA", "source": "Local", "member_ids": members},
+			}}
+		},
+		"/api/edit_user_group":           ok,
+		"/api/add_user_group_members":    ok,
+		"/api/remove_user_group_members": ok,
+	})
+}
+
+func testUserGroupModel(t *testing.T, members ...int64) UserGroupResourceModel {
+	model := UserGroupResourceModel{
+		ID:        types.Int64Value(120),
+		Name:      types.StringValue("DBA"),
+		MemberIDs: types.SetNull(types.Int64Type),
+	}
+	if members != nil {
+		var diags diag.Diagnostics
+		model.MemberIDs = int64sToSet(members, &diags)
+		if diags.HasError() {
+			t.Fatal(diags)
+		}
+	}
+	return model
+}
+
+func TestUserGroupResourceReadMembers(t *testing.T) {
+	ctx := context.Background()
+	s := resourceSchema(t, &UserGroupResource{})
+	userGroupServer(t, 5, 6)
+
+	tests := []struct {
+		name  string
+		state UserGroupResourceModel
+		want  types.Set
+	}{
+		{"members managed outside Terraform", testUserGroupModel(t), types.SetNull(types.Int64Type)},
+		{"members managed by Terraform", testUserGroupModel(t, 5), testUserGroupModel(t, 5, 6).MemberIDs},
+	}
+	for _, test := range tests {
+		resp := resource.ReadResponse{State: newState(t, s, &test.state)}
+		(&UserGroupResource{}).Read(ctx, resource.ReadRequest{State: newState(t, s, &test.state)}, &resp)
+		if resp.Diagnostics.HasError() {
+			t.Fatalf("%s: %v", test.name, resp.Diagnostics)
+		}
+		var got UserGroupResourceModel
+		getState(t, resp.State, &got)
+		if !got.MemberIDs.Equal(test.want) {
+			t.Errorf("%s: member_ids = %s, want %s", test.name, got.MemberIDs, test.want)
+		}
+	}
+}
+
+func TestUserGroupResourceUpdateMembers(t *testing.T) {
+	ctx := context.Background()
+	s := resourceSchema(t, &UserGroupResource{})
+
+	t.Run("members managed outside Terraform", func(t *testing.T) {
+		fake := userGroupServer(t, 5, 6)
+		state := testUserGroupModel(t)
+		plan := testUserGroupModel(t)
+		plan.Description = types.StringValue("Database administrators")
+		resp := resource.UpdateResponse{State: tfsdk.State{Schema: s}}
+		(&UserGroupResource{}).Update(ctx, resource.UpdateRequest{Plan: newPlan(t, s, &plan), State: newState(t, s, &state)}, &resp)
+		if resp.Diagnostics.HasError() {
+			t.Fatal(resp.Diagnostics)
+		}
+		if len(fake.calls("/api/edit_user_group")) != 1 {
+			t.Error("group not edited")
+		}
+		if calls := len(fake.calls("/api/add_user_group_members")) + len(fake.calls("/api/remove_user_group_members")); calls != 0 {
+			t.Errorf("%d membership changes sent, want none", calls)
+		}
+	})
+
+	t.Run("members managed by Terraform", func(t *testing.T) {
+		fake := userGroupServer(t, 5, 6)
+		state := testUserGroupModel(t, 5, 6)
+		plan := testUserGroupModel(t, 6, 7)
+		resp := resource.UpdateResponse{State: tfsdk.State{Schema: s}}
+		(&UserGroupResource{}).Update(ctx, resource.UpdateRequest{Plan: newPlan(t, s, &plan), State: newState(t, s, &state)}, &resp)
+		if resp.Diagnostics.HasError() {
+			t.Fatal(resp.Diagnostics)
+		}
+		if len(fake.calls("/api/edit_user_group")) != 0 {
+			t.Error("group edited although name and description are unchanged")
+		}
+		for apiURL, want := range map[string]string{
+			"/api/remove_user_group_members": "[5]",
+			"/api/add_user_group_members":    "[7]",
+		} {
+			calls := fake.calls(apiURL)
+			if len(calls) != 1 || stringifyValue(calls[0]["user_ids"]) != want {
+				t.Errorf("%s calls = %v, want user_ids %s", apiURL, calls, want)
+			}
+		}
+	})
+}
@@ -0,0 +1,209 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ datasource.DataSource = &Users{}
+
+func users() datasource.DataSource {
+	return &Users{}
+}
+
+type Users struct {
+	client *http.Client
+}
+
+type UsersModel struct {
+	Usernames  []types.String     `tfsdk:"usernames"`
+	Emails     []types.String     `tfsdk:"emails"`
+	MaxResults types.Int64        `tfsdk:"max_results"`
+	Users      []UserSummaryModel `tfsdk:"users"`
+	IDs        map[string]int64   `tfsdk:"ids"`
+}
+
+type UserSummaryModel struct {
+	UserID    types.Int64  `tfsdk:"user_id"`
+	Username  types.String `tfsdk:"username"`
+	Email     types.String `tfsdk:"email"`
+	FirstName types.String `tfsdk:"first_name"`
+	LastName  types.String `tfsdk:"last_name"`
+	Status    types.String `tfsdk:"status"`
+}
+
+// userEntry is a user as returned by get_users.
+type userEntry struct {
+	ID        int64
+	Username  string
+	Email     string
+	FirstName string
+	LastName  string
+	Status    string
+}
+
+func (d *Users) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_users"
+}
+
+func (d *Users) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Lists Securden users, optionally resolving a set of usernames or email addresses.",
+
+		Attributes: map[string]schema.Attribute{
+			"usernames": schema.ListAttribute{
+				ElementType:         types.StringType,
+				Optional:            true,
+				MarkdownDescription: "Usernames to resolve. The read fails if any of them does not exist.",
+			},
+			"emails": schema.ListAttribute{
+				ElementType:         types.StringType,
+				Optional:            true,
+				MarkdownDescription: "Email addresses to resolve. The read fails if any of them does not exist.",
+			},
+			"max_results": maxResultsAttribute("Maximum number of users to return, the first ones in username order. Defaults to no limit."),
+			"users": schema.ListNestedAttribute{
+				Computed:            true,
+				MarkdownDescription: "The requested users, or every user when neither `usernames` nor `emails` is set.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: userSummaryAttributes(),
+				},
+			},
+			"ids": schema.MapAttribute{
+				ElementType:         types.Int64Type,
+				Computed:            true,
+				MarkdownDescription: "A map of username to user ID for the returned users.",
+			},
+		},
+	}
+}
+
+func userSummaryAttributes() map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"user_id": schema.Int64Attribute{
+			Computed:            true,
+			MarkdownDescription: "Unique identifier of the user.",
+		},
+		"username": schema.StringAttribute{
+			Computed:            true,
+			MarkdownDescription: "Login name of the user.",
+		},
+		"email": schema.StringAttribute{
+			Computed:            true,
+			MarkdownDescription: "Email address of the user.",
+		},
+		"first_name": schema.StringAttribute{
+			Computed:            true,
+			MarkdownDescription: "First name of the user.",
+		},
+		"last_name": schema.StringAttribute{
+			Computed:            true,
+			MarkdownDescription: "Last name of the user.",
+		},
+		"status": schema.StringAttribute{
+			Computed:            true,
+			MarkdownDescription: "Status of the user account, for example `Active` or `Disabled`.",
+		},
+	}
+}
+
+func (d *Users) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	client, ok := req.ProviderData.(*http.Client)
+	if !ok {
+		resp.Diagnostics.AddWarning(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *http.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+	d.client = client
+}
+
+func (d *Users) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data UsersModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	// Lookups and the sort by username need the full listing, the cap is
+	// applied to the sorted result.
+	maxResults := int(data.MaxResults.ValueInt64())
+	entries, code, message := get_users(ctx)
+	if code != 200 {
+		resp.Diagnostics.AddError(fmt.Sprintf("%d - %s", code, message), "")
+		return
+	}
+	selected := entries
+	if data.Usernames != nil || data.Emails != nil {
+		selected = []userEntry{}
+		seen := make(map[int64]bool)
+		var missing []string
+		for _, lookup := range []struct {
+			values []types.String
+			field  string
+		}{{data.Usernames, "username"}, {data.Emails, "email"}} {
+			for _, value := range lookup.values {
+				user, ok := findUser(entries, lookup.field, value.ValueString())
+				if !ok {
+					missing = append(missing, fmt.Sprintf("%s %q", lookup.field, value.ValueString()))
+					continue
+				}
+				if !seen[user.ID] {
+					seen[user.ID] = true
+					selected = append(selected, user)
+				}
+			}
+		}
+		if len(missing) > 0 {
+			resp.Diagnostics.AddError("404 - User not found", "No user exists with "+strings.Join(missing, ", ")+".")
+			return
+		}
+	}
+	sort.Slice(selected, func(i, j int) bool { return selected[i].Username < selected[j].Username })
+	if maxResults > 0 && len(selected) > maxResults {
+		selected = selected[:maxResults]
+	}
+	data.Users = []UserSummaryModel{}
+	data.IDs = make(map[string]int64)
+	for _, user := range selected {
+		data.Users = append(data.Users, user.summary())
+		data.IDs[user.Username] = user.ID
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (user userEntry) summary() UserSummaryModel {
+	return UserSummaryModel{
+		UserID:    types.Int64Value(user.ID),
+		Username:  types.StringValue(user.Username),
+		Email:     types.StringValue(user.Email),
+		FirstName: types.StringValue(user.FirstName),
+		LastName:  types.StringValue(user.LastName),
+		Status:    types.StringValue(user.Status),
+	}
+}
+
+// findUser looks a user up by username or email. Both are matched without
+// regard to case, the way Securden matches them at login.
+func findUser(entries []userEntry, field string, value string) (userEntry, bool) {
+	for _, user := range entries {
+		candidate := user.Username
+		if field == "email" {
+			candidate = user.Email
+		}
+		if candidate != "" && strings.EqualFold(candidate, value) {
+			return user, true
+		}
+	}
+	return userEntry{}, false
+}
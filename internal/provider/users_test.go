@@ -0,0 +1,40 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestUsersRead(t *testing.T) {
+	newFakeServer(t, map[string]fakeHandler{
+		"/secretsmanagement/get_users": func(params map[string]any) any {
+			return map[string]any{"status_code": 200, "users": []map[string]any{
+				{"user_id": 3, "username": "carol", "email": "carol@example.com"},
+				{"user_id": 1, "username": "alice", "email": "alice@example.com"},
+				{"user_id": 2, "username": "bob", "email": "bob@example.com"},
+			}}
+		},
+	})
+
+	var got UsersModel
+	if diags := readDataSource(t, &Users{}, &UsersModel{MaxResults: types.Int64Value(2)}, &got); diags.HasError() {
+		t.Fatal(diags)
+	}
+	if len(got.Users) != 2 || got.Users[0].Username.ValueString() != "alice" || got.Users[1].Username.ValueString() != "bob" {
+		t.Errorf("users = %v, want the first two in username order", got.Users)
+	}
+
+	config := UsersModel{Usernames: []types.String{types.StringValue("carol")}, Emails: []types.String{types.StringValue("ALICE@example.com")}}
+	if diags := readDataSource(t, &Users{}, &config, &got); diags.HasError() {
+		t.Fatal(diags)
+	}
+	if got.IDs["carol"] != 3 || got.IDs["alice"] != 1 || len(got.IDs) != 2 {
+		t.Errorf("ids = %v", got.IDs)
+	}
+
+	config = UsersModel{Usernames: []types.String{types.StringValue("dave")}}
+	if diags := readDataSource(t, &Users{}, &config, &got); !diags.HasError() {
+		t.Error("expected an error for an unknown username")
+	}
+}
@@ -0,0 +1,111 @@
+package provider
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"net"
+	"regexp"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+)
+
+var hostnamePattern = regexp.MustCompile(`^([A-Za-z0-9]([A-Za-z0-9-]{0,61}[A-Za-z0-9])?)(\.[A-Za-z0-9]([A-Za-z0-9-]{0,61}[A-Za-z0-9])?)*\.?$`)
+
+var _ validator.String = expirationDateValidator{}
+var _ validator.String = hostAddressValidator{}
+var _ validator.String = base64ContentValidator{}
+
+// expirationDateValidator checks that a value is a DD/MM/YYYY date, an RFC
+// 3339 timestamp or a relative duration, rejecting dates such as 31/02/2025.
+type expirationDateValidator struct{}
+
+func expirationDate() validator.String {
+	return expirationDateValidator{}
+}
+
+func (v expirationDateValidator) Description(ctx context.Context) string {
+	return "value must be a DD/MM/YYYY date, an RFC 3339 timestamp or a duration such as +90d"
+}
+
+func (v expirationDateValidator) MarkdownDescription(ctx context.Context) string {
+	return "value must be a `DD/MM/YYYY` date, an RFC 3339 timestamp or a duration such as `+90d`"
+}
+
+func (v expirationDateValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+	value := req.ConfigValue.ValueString()
+	if _, err := parseExpirationDate(value, time.Now()); err != nil {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid Date",
+			fmt.Sprintf("Attribute %s %s, got: %q", req.Path, v.Description(ctx), value),
+		)
+	}
+}
+
+// hostAddressValidator checks that a value is an IPv4 or IPv6 address or a
+// RFC 1123 hostname.
+type hostAddressValidator struct{}
+
+func hostAddress() validator.String {
+	return hostAddressValidator{}
+}
+
+func (v hostAddressValidator) Description(ctx context.Context) string {
+	return "value must be an IP address or a hostname"
+}
+
+func (v hostAddressValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v hostAddressValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+	value := req.ConfigValue.ValueString()
+	if net.ParseIP(value) != nil {
+		return
+	}
+	if len(value) <= 253 && hostnamePattern.MatchString(value) {
+		return
+	}
+	resp.Diagnostics.AddAttributeError(
+		req.Path,
+		"Invalid Address",
+		fmt.Sprintf("Attribute %s %s, got: %q", req.Path, v.Description(ctx), value),
+	)
+}
+
+// base64ContentValidator checks that a value is standard base64 encoded, as
+// returned by the filebase64 function.
+type base64ContentValidator struct{}
+
+func base64Content() validator.String {
+	return base64ContentValidator{}
+}
+
+func (v base64ContentValidator) Description(ctx context.Context) string {
+	return "value must be base64 encoded"
+}
+
+func (v base64ContentValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v base64ContentValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+	if _, err := base64.StdEncoding.DecodeString(req.ConfigValue.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid File Content",
+			fmt.Sprintf("Attribute %s %s: %v", req.Path, v.Description(ctx), err),
+		)
+	}
+}
@@ -0,0 +1,44 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestStringValidators(t *testing.T) {
+	tests := []struct {
+		name      string
+		validator validator.String
+		value     types.String
+		wantError bool
+	}{
+		{"date", expirationDate(), types.StringValue("31/12/2030"), false},
+		{"timestamp", expirationDate(), types.StringValue("2030-12-31T00:00:00Z"), false},
+		{"duration", expirationDate(), types.StringValue("+90d"), false},
+		{"impossible date", expirationDate(), types.StringValue("31/02/2030"), true},
+		{"month first date", expirationDate(), types.StringValue("12/31/2030"), true},
+		{"ipv4", hostAddress(), types.StringValue("10.0.0.12"), false},
+		{"ipv6", hostAddress(), types.StringValue("fd00::12"), false},
+		{"hostname", hostAddress(), types.StringValue("db01.example.com"), false},
+		{"url", hostAddress(), types.StringValue("https://db01.example.com"), true},
+		{"hostname with space", hostAddress(), types.StringValue("db 01"), true},
+		{"base64", base64Content(), types.StringValue("aGVsbG8="), false},
+		{"not base64", base64Content(), types.StringValue("hello!"), true},
+		{"null", hostAddress(), types.StringNull(), false},
+		{"unknown", expirationDate(), types.StringUnknown(), false},
+	}
+	for _, test := range tests {
+		var resp validator.StringResponse
+		test.validator.ValidateString(context.Background(), validator.StringRequest{
+			Path:        path.Root("value"),
+			ConfigValue: test.value,
+		}, &resp)
+		if resp.Diagnostics.HasError() != test.wantError {
+			t.Errorf("%s: got errors %v, want error %t", test.name, resp.Diagnostics, test.wantError)
+		}
+	}
+}